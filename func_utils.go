@@ -1,17 +1,42 @@
 package slicesutils
 
 import (
+	"context"
 	"fmt"
 	"runtime"
 )
 
+// PanicError is the error returned by the Safe* helpers when they recover from a panic.
+// If the recovered value is itself an error, it can be reached through errors.Is and errors.As.
+type PanicError struct {
+	// Value is the value the code panicked with.
+	Value any
+	// Stack is the stack trace captured when recovering, if any.
+	Stack string
+}
+
+func (e *PanicError) Error() string {
+	if e.Stack == "" {
+		return fmt.Sprintf("panic: %v", e.Value)
+	}
+	return fmt.Sprintf("panic: %v\nStack trace:\n%s", e.Value, e.Stack)
+}
+
+// Unwrap returns the recovered value if it is an error, or nil otherwise.
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
 // SafeExcecute executes a given function and recovers from any panic that occurs during its execution.
 // It returns the output of the function and any error that occurred.
-// If a panic occurs, it intercepts the panic and returns it as an error.
+// If a panic occurs, it intercepts the panic and returns it as a *PanicError wrapping the recovered value.
 func SafeExcecute[T_out any](fn func() (T_out, error)) (output T_out, err error) {
 	defer func() {
 		if r := recover(); r != nil {
-			err = r.(error)
+			err = &PanicError{Value: r}
 		}
 	}()
 
@@ -20,13 +45,12 @@ func SafeExcecute[T_out any](fn func() (T_out, error)) (output T_out, err error)
 }
 
 // SafeExcecuteWithStackTrace executes a function that returns a value and an error,
-// and ensures that any panic during the execution is recovered and converted into an error
+// and ensures that any panic during the execution is recovered and converted into a *PanicError
 // with a stack trace.
 func SafeExcecuteWithStackTrace[T_out any](fn func() (T_out, error)) (output T_out, err error) {
 	defer func() {
 		if r := recover(); r != nil {
-			err = r.(error)
-			err = fmt.Errorf("panic: %v\nStack trace:\n%s", err, getErrWithStackTrace())
+			err = &PanicError{Value: r, Stack: getErrWithStackTrace()}
 		}
 	}()
 
@@ -34,6 +58,37 @@ func SafeExcecuteWithStackTrace[T_out any](fn func() (T_out, error)) (output T_o
 	return
 }
 
+// SafeExcecuteCtx executes fn like SafeExcecute, recovering from any panic, but stops waiting
+// for it as soon as the context is cancelled or times out, returning ctx.Err() in that case.
+// fn receives the context and should honor it: the function can't forcibly kill a
+// non-cooperative fn, which keeps running in the background until it returns.
+func SafeExcecuteCtx[T_out any](ctx context.Context, fn func(context.Context) (T_out, error)) (output T_out, err error) {
+	if err = ctx.Err(); err != nil {
+		return output, err
+	}
+
+	type result struct {
+		output T_out
+		err    error
+	}
+
+	// Buffered so that fn's goroutine can always finish even if nobody is waiting anymore
+	resultChan := make(chan result, 1)
+	go func() {
+		out, errAux := SafeExcecute(func() (T_out, error) {
+			return fn(ctx)
+		})
+		resultChan <- result{output: out, err: errAux}
+	}()
+
+	select {
+	case res := <-resultChan:
+		return res.output, res.err
+	case <-ctx.Done():
+		return output, ctx.Err()
+	}
+}
+
 func getErrWithStackTrace() string {
 	buff := make([]byte, 4096)
 	n := runtime.Stack(buff, false)
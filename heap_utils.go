@@ -0,0 +1,36 @@
+package slicesutils
+
+// funcHeap is a container/heap implementation ordered by a less function,
+// used internally by the helpers that need to keep a bounded selection of elements.
+// The element for which less returns true against every other element sits at the top.
+type funcHeap[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+func (h *funcHeap[T]) Len() int {
+	return len(h.items)
+}
+
+func (h *funcHeap[T]) Less(i, j int) bool {
+	return h.less(h.items[i], h.items[j])
+}
+
+func (h *funcHeap[T]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+func (h *funcHeap[T]) Push(x any) {
+	h.items = append(h.items, x.(T))
+}
+
+func (h *funcHeap[T]) Pop() any {
+	last := len(h.items) - 1
+	item := h.items[last]
+
+	var zero T
+	h.items[last] = zero
+	h.items = h.items[:last]
+
+	return item
+}
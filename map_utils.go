@@ -0,0 +1,40 @@
+package slicesutils
+
+// TransformMapValues returns a new map with the same keys as m, where every value
+// has been transformed by fn. The input map is not modified.
+func TransformMapValues[K comparable, V any, V2 any](m map[K]V, fn func(V) V2) map[K]V2 {
+	result := make(map[K]V2, len(m))
+
+	for key, value := range m {
+		result[key] = fn(value)
+	}
+
+	return result
+}
+
+// TransformMapKeys returns a new map with the same values as m, where every key
+// has been transformed by fn. The input map is not modified.
+//
+// If fn maps several keys to the same new key, only one of their values is kept.
+// Since map iteration order is unspecified, which one "wins" is unspecified too.
+func TransformMapKeys[K comparable, K2 comparable, V any](m map[K]V, fn func(K) K2) map[K2]V {
+	result := make(map[K2]V, len(m))
+
+	for key, value := range m {
+		result[fn(key)] = value
+	}
+
+	return result
+}
+
+// MapToSlice flattens a map into a slice by applying fn to every key/value pair.
+// The order of the resulting slice is unspecified, matching Go's map iteration order.
+func MapToSlice[K comparable, V any, O any](m map[K]V, fn func(K, V) O) []O {
+	result := make([]O, 0, len(m))
+
+	for key, value := range m {
+		result = append(result, fn(key, value))
+	}
+
+	return result
+}
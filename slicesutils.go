@@ -2,12 +2,31 @@ package slicesutils
 
 import (
 	"cmp"
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
 	"math"
+	"math/rand"
+	"reflect"
 	"runtime"
 	"sort"
 	"sync"
+	"time"
 )
 
+// Number is a constraint that permits any integer or floating-point type,
+// for the helpers that need to do arithmetic over the elements, such as Sum.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// ErrIndexOutOfRange is returned by the index-based helpers when the given index
+// falls outside of the slice.
+var ErrIndexOutOfRange = errors.New("index out of range")
+
 // Max returns the maximum value in the provided slice.
 // If no elements are provided, it panics with "No element provided to Max".
 func Max[T cmp.Ordered](elements ...T) T {
@@ -24,6 +43,9 @@ func Max[T cmp.Ordered](elements ...T) T {
 	return maxValue
 }
 
+// MaxFunc returns the maximum value in the provided slice, as determined by the max function,
+// which receives the current maximum and the next element and returns the bigger of both.
+// If no elements are provided, it panics with "No element provided to Max".
 func MaxFunc[T any](max func(T, T) T, elements ...T) T {
 	if len(elements) == 0 {
 		panic("No element provided to Max")
@@ -36,6 +58,191 @@ func MaxFunc[T any](max func(T, T) T, elements ...T) T {
 	return maxValue
 }
 
+// Min returns the minimum value in the provided slice.
+// If no elements are provided, it panics with "No element provided to Min".
+func Min[T cmp.Ordered](elements ...T) T {
+	if len(elements) == 0 {
+		panic("No element provided to Min")
+	}
+
+	minValue := elements[0]
+	for _, num := range elements {
+		if num < minValue {
+			minValue = num
+		}
+	}
+	return minValue
+}
+
+// MinBy returns the element of the slice with the smallest key, as returned by keyFunc,
+// without sorting or modifying the slice. Ties are resolved in favor of the first element found.
+// It returns the zero value and false if the slice is empty.
+func MinBy[I any, K cmp.Ordered, S ~[]I](slice S, keyFunc func(I) K) (I, bool) {
+	if len(slice) == 0 {
+		var zero I
+		return zero, false
+	}
+
+	minItem := slice[0]
+	minKey := keyFunc(minItem)
+	for _, item := range slice[1:] {
+		if key := keyFunc(item); key < minKey {
+			minItem, minKey = item, key
+		}
+	}
+	return minItem, true
+}
+
+// MaxBy returns the element of the slice with the biggest key, as returned by keyFunc,
+// without sorting or modifying the slice. Ties are resolved in favor of the first element found.
+// It returns the zero value and false if the slice is empty.
+func MaxBy[I any, K cmp.Ordered, S ~[]I](slice S, keyFunc func(I) K) (I, bool) {
+	if len(slice) == 0 {
+		var zero I
+		return zero, false
+	}
+
+	maxItem := slice[0]
+	maxKey := keyFunc(maxItem)
+	for _, item := range slice[1:] {
+		if key := keyFunc(item); key > maxKey {
+			maxItem, maxKey = item, key
+		}
+	}
+	return maxItem, true
+}
+
+// ArgMin returns the index of the smallest element of the slice, the first one on ties.
+// It returns -1 and false if the slice is empty.
+func ArgMin[I cmp.Ordered, S ~[]I](slice S) (index int, ok bool) {
+	if len(slice) == 0 {
+		return -1, false
+	}
+
+	for i := range slice {
+		if slice[i] < slice[index] {
+			index = i
+		}
+	}
+	return index, true
+}
+
+// ArgMax returns the index of the biggest element of the slice, the first one on ties.
+// It returns -1 and false if the slice is empty.
+func ArgMax[I cmp.Ordered, S ~[]I](slice S) (index int, ok bool) {
+	if len(slice) == 0 {
+		return -1, false
+	}
+
+	for i := range slice {
+		if slice[i] > slice[index] {
+			index = i
+		}
+	}
+	return index, true
+}
+
+// MinMax returns both the minimum and the maximum values of the slice in a single pass,
+// comparing the elements in pairs so that it needs about 1.5 comparisons per element instead of 2.
+// It returns false if the slice is empty.
+func MinMax[I cmp.Ordered, S ~[]I](slice S) (minValue I, maxValue I, ok bool) {
+	if len(slice) == 0 {
+		return minValue, maxValue, false
+	}
+
+	minValue, maxValue = slice[0], slice[0]
+	for i := 1; i < len(slice); i += 2 {
+		small, big := slice[i], slice[i]
+		if i+1 < len(slice) {
+			if slice[i+1] < small {
+				small = slice[i+1]
+			} else {
+				big = slice[i+1]
+			}
+		}
+
+		if small < minValue {
+			minValue = small
+		}
+		if big > maxValue {
+			maxValue = big
+		}
+	}
+
+	return minValue, maxValue, true
+}
+
+// Sum returns the sum of all the elements of the slice.
+// It returns the zero value for an empty slice.
+func Sum[I Number, S ~[]I](slice S) I {
+	var sum I
+	for _, item := range slice {
+		sum += item
+	}
+	return sum
+}
+
+// SumBy returns the sum of the values returned by keyFunc for every element of the slice.
+// It returns the zero value for an empty slice.
+func SumBy[I any, N Number, S ~[]I](slice S, keyFunc func(I) N) N {
+	var sum N
+	for _, item := range slice {
+		sum += keyFunc(item)
+	}
+	return sum
+}
+
+// Clamp bounds every element of the slice into the range [low, high], replacing elements
+// below low with low and elements above high with high.
+// The slice is modified in place and returned.
+// It panics with "Clamp: low is greater than high" if the range is empty.
+func Clamp[I cmp.Ordered, S ~[]I](slice S, low, high I) S {
+	if low > high {
+		panic("Clamp: low is greater than high")
+	}
+
+	for i, item := range slice {
+		if item < low {
+			slice[i] = low
+		} else if item > high {
+			slice[i] = high
+		}
+	}
+	return slice
+}
+
+// Normalize scales the values of the slice into the [0, 1] range, mapping the minimum
+// value to 0 and the maximum value to 1. The input slice is not modified.
+// If all the values are equal there is no range to scale to, so every value is mapped to 0.
+// It returns an empty slice for an empty input.
+func Normalize[I ~float32 | ~float64, S ~[]I](slice S) []float64 {
+	normalized := make([]float64, len(slice))
+	if len(slice) == 0 {
+		return normalized
+	}
+
+	minValue, maxValue, _ := MinMax(slice)
+
+	// The values are converted before subtracting, so wide float32 ranges don't overflow.
+	// For float64 the range itself may still overflow, in which case both operands are halved
+	low, high := float64(minValue), float64(maxValue)
+	scale := 1.0
+	valueRange := high - low
+	if math.IsInf(valueRange, 0) {
+		scale = 0.5
+		valueRange = high*scale - low*scale
+	}
+	if valueRange == 0 {
+		return normalized
+	}
+
+	for i, item := range slice {
+		normalized[i] = (float64(item)*scale - low*scale) / valueRange
+	}
+
+	return normalized
+}
+
 // ParallelMap applies the given map function concurrently to each element in the input slice.
 // It creates a fixed number of worker goroutines to process the elements in parallel.
 // The input slice is divided into chunks and each chunk is processed by a worker goroutine.
@@ -43,11 +250,111 @@ func MaxFunc[T any](max func(T, T) T, elements ...T) T {
 // The map function takes an element of type T as input and returns an element of type U.
 // The number of worker goroutines is determined by the number of available CPU cores.
 // This function blocks until all worker goroutines have completed their tasks.
+//
+// If the map function panics, the panic is recovered on the worker goroutine and raised again
+// on the caller's goroutine as a *PanicError once all workers are done, so that it can be
+// recovered by the caller instead of crashing the program. Use ParallelMapErr to get it as an error.
 func ParallelMap[I any, O any, S ~[]I](inputSlice S, mapFunc func(I) O) []O {
+	return ParallelMapWithWorkers(inputSlice, 0, mapFunc)
+}
+
+// ParallelMapWithWorkers works like ParallelMap but runs the given number of worker goroutines,
+// e.g. many more than CPU cores for IO-bound map functions, or fewer for memory-heavy ones.
+// If workers is less than or equal to 0, the number of available CPU cores is used.
+func ParallelMapWithWorkers[I any, O any, S ~[]I](inputSlice S, workers int, mapFunc func(I) O) []O {
 	if inputSlice == nil {
 		return []O{}
 	}
 
+	return parallelMapInto(make([]O, len(inputSlice)), inputSlice, workers, mapFunc)
+}
+
+// ParallelMapInto works like ParallelMap but writes the results into dst instead of
+// allocating a new slice, which avoids a large allocation when mapping repeatedly in a hot loop.
+// dst is resized to len(src), reusing its backing array when it has enough capacity,
+// and the results keep the same order as src.
+//
+// The contents of dst are overwritten, so it must have its own backing array
+// that does not overlap with src or any other slice still in use.
+func ParallelMapInto[I any, O any, S ~[]I](dst []O, src S, mapFunc func(I) O) []O {
+	if cap(dst) < len(src) {
+		dst = make([]O, len(src))
+	}
+
+	return parallelMapInto(dst[:len(src)], src, 0, mapFunc)
+}
+
+// parallelMapInto maps every element of src into the same index of dst, which must have the same length.
+func parallelMapInto[I any, O any, S ~[]I](dst []O, src S, workers int, mapFunc func(I) O) []O {
+	runWorkers(workerCount(workers, len(src)), len(src), func(_ int, idx int) {
+		dst[idx] = mapFunc(src[idx])
+	})
+
+	return dst
+}
+
+// workerCount returns the number of worker goroutines to use for n items:
+// workers, or the number of available CPU cores if workers is less than or equal to 0,
+// but never more than n.
+func workerCount(workers int, n int) int {
+	numWorkers := workers
+	if numWorkers <= 0 {
+		numWorkers = runtime.NumCPU()
+	}
+	if n < numWorkers {
+		numWorkers = n
+	}
+	return numWorkers
+}
+
+// runWorkers calls work for every index in [0, n) from numWorkers goroutines, passing the
+// number of the worker, in [0, numWorkers), along with the index. It returns once all work is done.
+// The first panic in work is raised again on the caller's goroutine as a *PanicError.
+func runWorkers(numWorkers int, n int, work func(worker int, idx int)) {
+	var wg sync.WaitGroup
+	var once sync.Once
+	var workerPanic *PanicError
+
+	inputChan := make(chan int, n)
+
+	// Start workers
+	for i := 0; i < numWorkers; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			// A panic on a worker goroutine can't be recovered by the caller,
+			// so it is captured here and raised again on the caller's goroutine
+			defer func() {
+				if r := recover(); r != nil {
+					once.Do(func() {
+						workerPanic = &PanicError{Value: r, Stack: getErrWithStackTrace()}
+					})
+				}
+			}()
+			for idx := range inputChan {
+				work(worker, idx)
+			}
+		}(i)
+	}
+
+	// Send index to workers
+	for i := 0; i < n; i++ {
+		inputChan <- i
+	}
+	close(inputChan)
+
+	wg.Wait()
+
+	if workerPanic != nil {
+		panic(workerPanic)
+	}
+}
+
+// ParallelMapErr works like ParallelMap but with a mapping function that can fail.
+// Panics in the mapping function are recovered and converted into errors, like SafeMap does.
+// If any element fails, the remaining work after it is skipped and the error of the failing
+// element with the lowest index is returned, so the result is deterministic.
+func ParallelMapErr[I any, O any, S ~[]I](inputSlice S, mapFunc func(I) (O, error)) ([]O, error) {
 	outputSlice := make([]O, len(inputSlice))
 	numWorkers := runtime.NumCPU()
 	if len(inputSlice) < numWorkers {
@@ -55,6 +362,9 @@ func ParallelMap[I any, O any, S ~[]I](inputSlice S, mapFunc func(I) O) []O {
 	}
 
 	var wg sync.WaitGroup
+	var mu sync.Mutex
+	firstErrIndex := len(inputSlice)
+	var firstErr error
 
 	inputChan := make(chan int, len(inputSlice))
 
@@ -64,7 +374,26 @@ func ParallelMap[I any, O any, S ~[]I](inputSlice S, mapFunc func(I) O) []O {
 		go func() {
 			defer wg.Done()
 			for idx := range inputChan {
-				outputSlice[idx] = mapFunc(inputSlice[idx])
+				mu.Lock()
+				skip := idx > firstErrIndex
+				mu.Unlock()
+				if skip {
+					continue
+				}
+
+				output, err := SafeExcecute(func() (O, error) {
+					return mapFunc(inputSlice[idx])
+				})
+				if err != nil {
+					mu.Lock()
+					if idx < firstErrIndex {
+						firstErrIndex = idx
+						firstErr = err
+					}
+					mu.Unlock()
+					continue
+				}
+				outputSlice[idx] = output
 			}
 		}()
 	}
@@ -77,7 +406,89 @@ func ParallelMap[I any, O any, S ~[]I](inputSlice S, mapFunc func(I) O) []O {
 
 	wg.Wait()
 
-	return outputSlice
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return outputSlice, nil
+}
+
+// MapStats holds the timing metrics collected by ParallelMapTimed.
+type MapStats struct {
+	// TotalDuration is the wall time spent by the whole parallel map.
+	TotalDuration time.Duration
+	// ItemsPerWorker holds the number of items processed by each worker goroutine.
+	ItemsPerWorker []int
+	// MinItemDuration is the shortest time spent mapping a single item.
+	MinItemDuration time.Duration
+	// MaxItemDuration is the longest time spent mapping a single item.
+	MaxItemDuration time.Duration
+	// AvgItemDuration is the average time spent mapping a single item.
+	AvgItemDuration time.Duration
+}
+
+// ParallelMapTimed works like ParallelMap but also returns timing metrics about the run,
+// which is useful when tuning batch jobs.
+// Every worker collects its own durations locally and they are merged once all workers are done,
+// so the instrumentation adds no contention between workers.
+// The results keep the same order as the input slice.
+// Like ParallelMap, a panic in the map function is raised again on the caller's goroutine as a *PanicError.
+func ParallelMapTimed[I any, O any, S ~[]I](slice S, mapFunc func(I) O) (results []O, stats MapStats) {
+	start := time.Now()
+
+	results = make([]O, len(slice))
+	numWorkers := workerCount(0, len(slice))
+
+	type workerStats struct {
+		count int
+		total time.Duration
+		min   time.Duration
+		max   time.Duration
+	}
+	perWorker := make([]workerStats, numWorkers)
+
+	// Every worker only touches its own entry of perWorker
+	runWorkers(numWorkers, len(slice), func(worker int, idx int) {
+		itemStart := time.Now()
+		results[idx] = mapFunc(slice[idx])
+		elapsed := time.Since(itemStart)
+
+		local := &perWorker[worker]
+		if local.count == 0 || elapsed < local.min {
+			local.min = elapsed
+		}
+		if elapsed > local.max {
+			local.max = elapsed
+		}
+		local.total += elapsed
+		local.count++
+	})
+
+	stats.ItemsPerWorker = make([]int, numWorkers)
+	var totalItemDuration time.Duration
+	// A measured duration can legitimately be 0 with a coarse clock,
+	// so whether a minimum was recorded is tracked separately
+	hasMin := false
+	for i, local := range perWorker {
+		stats.ItemsPerWorker[i] = local.count
+		if local.count == 0 {
+			continue
+		}
+		if !hasMin || local.min < stats.MinItemDuration {
+			stats.MinItemDuration = local.min
+			hasMin = true
+		}
+		if local.max > stats.MaxItemDuration {
+			stats.MaxItemDuration = local.max
+		}
+		totalItemDuration += local.total
+	}
+	if len(slice) > 0 {
+		stats.AvgItemDuration = totalItemDuration / time.Duration(len(slice))
+	}
+	stats.TotalDuration = time.Since(start)
+
+	return results, stats
 }
 
 // Map applies a mapping function to each element of the input slice and returns
@@ -92,6 +503,16 @@ func Map[I any, O any, S ~[]I](inputSlice S, mapFunc func(I) O) []O {
 	return outputSlice
 }
 
+// MapInPlace applies a mapping function to each element of the slice, overwriting the element
+// with the result, and returns the same slice. It avoids the allocation of Map when the
+// input and output types are the same.
+func MapInPlace[I any, S ~[]I](slice S, fn func(I) I) S {
+	for i, item := range slice {
+		slice[i] = fn(item)
+	}
+	return slice
+}
+
 // SafeMap applies a mapping function to each element of an input slice, returning a new slice
 // with the results. If the mapping function returns an error for any element or panics, SafeMap will
 // return that error and halt further processing.
@@ -130,6 +551,21 @@ func Reduce[I any, O any, S ~[]I](inputSlice S, reduceFunc func(O, I) O, initial
 	return accumulator
 }
 
+// Scan works like Reduce but returns every intermediate accumulator value instead of just the last one,
+// so the result has the same length as the input slice.
+// For example, a running sum of [1, 2, 3] with an initialValue of 0 returns [1, 3, 6].
+func Scan[I any, O any, S ~[]I](slice S, fn func(O, I) O, initialValue O) []O {
+	accumulations := make([]O, len(slice))
+	accumulator := initialValue
+
+	for i, item := range slice {
+		accumulator = fn(accumulator, item)
+		accumulations[i] = accumulator
+	}
+
+	return accumulations
+}
+
 // SafeReduce is a generic function that safely reduces a slice of input elements
 // into a single output value by applying a user-defined reduce function. It ensures
 // that if an error is encountered during the reduction process, the reduce stops and returns the error.
@@ -154,7 +590,8 @@ func SafeReduce[I any, O any, S ~[]I](inputSlice S, reduceFunc func(O, I) (O, er
 // Filter applies a filter function to each element in the inputSlice and returns a new slice
 // containing only the elements for which the filter function returns true.
 // The filter function takes an element of type T as input and returns a boolean value.
-// The inputSlice is not modified.
+// The kept elements are moved to the front of inputSlice, so its backing array is reused and
+// the original contents are overwritten. Use FilterCopy to keep the input slice untouched.
 func Filter[I any, S ~[]I](inputSlice S, filterFunc func(I) bool) S {
 	newSliceLen := 0
 
@@ -168,6 +605,65 @@ func Filter[I any, S ~[]I](inputSlice S, filterFunc func(I) bool) S {
 	return inputSlice[:newSliceLen]
 }
 
+// FilterCopy works like Filter but returns a newly allocated slice,
+// leaving the input slice untouched.
+func FilterCopy[I any, S ~[]I](slice S, filterFunc func(I) bool) S {
+	filtered := make(S, 0)
+
+	for _, item := range slice {
+		if filterFunc(item) {
+			filtered = append(filtered, item)
+		}
+	}
+
+	return filtered
+}
+
+// Partition splits the slice in a single pass into the elements for which the predicate
+// returns true and the rest, preserving the original order in both.
+// Unlike Filter, both results are newly allocated, so the input slice is not modified.
+func Partition[I any, S ~[]I](slice S, predicate func(I) bool) (matched S, unmatched S) {
+	matched = make(S, 0)
+	unmatched = make(S, 0)
+
+	for _, item := range slice {
+		if predicate(item) {
+			matched = append(matched, item)
+			continue
+		}
+		unmatched = append(unmatched, item)
+	}
+
+	return matched, unmatched
+}
+
+// Extract splits the slice in a single pass into the elements to keep (predicate returns false)
+// and the elements pulled out of it (predicate returns true), preserving the original order in both.
+// It is Partition with the results swapped; the input slice is not modified.
+func Extract[I any, S ~[]I](slice S, predicate func(I) bool) (kept S, removed S) {
+	removed, kept = Partition(slice, predicate)
+	return kept, removed
+}
+
+// FilterType returns the elements of the slice that hold a value of type O, converted to O.
+// It is meant for heterogeneous slices such as []any or []SomeInterface and uses a type
+// assertion per element, so elements of any other type are silently dropped.
+//
+// Example usage:
+//
+//	foos := FilterType[*Foo]([]any{&Foo{}, "bar", &Foo{}}) // foos has 2 elements
+func FilterType[O any, I any, S ~[]I](slice S) []O {
+	result := make([]O, 0)
+
+	for _, item := range slice {
+		if converted, ok := any(item).(O); ok {
+			result = append(result, converted)
+		}
+	}
+
+	return result
+}
+
 // Sort sorts a slice of any type in place based on the provided less function.
 // The less function should return true if the first argument is considered to be less than the second.
 func Sort[I any, S ~[]I](slice S, less func(i, j I) bool) S {
@@ -177,14 +673,84 @@ func Sort[I any, S ~[]I](slice S, less func(i, j I) bool) S {
 	return slice
 }
 
+// SortBy sorts a slice in place in ascending order of the key returned by keyFunc.
+func SortBy[I any, K cmp.Ordered, S ~[]I](slice S, keyFunc func(I) K) S {
+	return Sort(slice, func(i, j I) bool {
+		return keyFunc(i) < keyFunc(j)
+	})
+}
+
+// SortByDesc sorts a slice in place in descending order of the key returned by keyFunc.
+func SortByDesc[I any, K cmp.Ordered, S ~[]I](slice S, keyFunc func(I) K) S {
+	return Sort(slice, func(i, j I) bool {
+		return keyFunc(i) > keyFunc(j)
+	})
+}
+
+// IsSorted reports whether the slice is sorted in ascending order.
+// Empty and single-element slices are always sorted.
+func IsSorted[I cmp.Ordered, S ~[]I](slice S) bool {
+	for i := 1; i < len(slice); i++ {
+		if slice[i] < slice[i-1] {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSortedBy reports whether the slice is sorted according to the provided less function.
+// Empty and single-element slices are always sorted.
+func IsSortedBy[I any, S ~[]I](slice S, less func(a, b I) bool) bool {
+	for i := 1; i < len(slice); i++ {
+		if less(slice[i], slice[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Reverse reverses the order of the elements of the slice in place and returns it.
+// Use ReverseCopy to keep the original slice untouched.
 func Reverse[I any, S ~[]I](slice S) S {
-	for i := 0; i <= len(slice)/2; i++ {
+	for i := 0; i < len(slice)/2; i++ {
 		j := len(slice) - i - 1
 		slice[i], slice[j] = slice[j], slice[i]
 	}
 	return slice
 }
 
+// ReverseCopy returns a newly allocated slice with the elements of the slice in reverse order.
+// Unlike Reverse, the input slice is not modified.
+func ReverseCopy[I any, S ~[]I](slice S) S {
+	reversed := make(S, len(slice))
+	for i, item := range slice {
+		reversed[len(slice)-i-1] = item
+	}
+	return reversed
+}
+
+// Rotate cyclically shifts the elements of the slice n positions to the left in place and returns it,
+// e.g. Rotate([1, 2, 3, 4, 5], 2) returns [3, 4, 5, 1, 2]. A negative n rotates to the right,
+// and n is reduced modulo the length of the slice so that big values wrap around.
+// It uses the reversal trick, so no extra memory is allocated.
+func Rotate[I any, S ~[]I](slice S, n int) S {
+	if len(slice) <= 1 {
+		return slice
+	}
+
+	n %= len(slice)
+	if n < 0 {
+		n += len(slice)
+	}
+	if n == 0 {
+		return slice
+	}
+
+	Reverse(slice[:n])
+	Reverse(slice[n:])
+	return Reverse(slice)
+}
+
 // WeightedSort sorts a slice of any type based on a weight function and a less function.
 // The weight function determines the primary sorting order by returning an integer weight for each element.
 // The less function is used as a secondary sorting order when two elements have the same weight.
@@ -202,6 +768,28 @@ func WeightedSort[I any, W cmp.Ordered, S ~[]I](slice S, getWeighfn func(I) W, l
 	return slice
 }
 
+// SortByKeys sorts a slice in place using a chain of three-way comparisons, each returning a negative
+// number, zero or a positive number when a sorts before, together with or after b.
+// The keys are applied in order until one of them is non-zero, so every key acts as a tiebreaker
+// for the previous ones. It generalizes WeightedSort to any number of levels.
+//
+// Example usage:
+//
+//	SortByKeys(people,
+//	    func(a, b Person) int { return cmp.Compare(a.LastName, b.LastName) },
+//	    func(a, b Person) int { return cmp.Compare(a.Age, b.Age) },
+//	)
+func SortByKeys[I any, S ~[]I](slice S, keys ...func(a, b I) int) S {
+	return Sort(slice, func(a, b I) bool {
+		for _, key := range keys {
+			if result := key(a, b); result != 0 {
+				return result < 0
+			}
+		}
+		return false
+	})
+}
+
 // RemoveElement returns a slice that contains the elements of the input slice
 // with at most n occurrences of element removed.
 //
@@ -261,6 +849,16 @@ func RemoveElements[I comparable, S ~[]I](slice S, elements ...I) S {
 	return slice[:newSliceLen]
 }
 
+// ForEachWhile calls fn for each element of the slice in order,
+// stopping as soon as fn returns false.
+func ForEachWhile[I any, S ~[]I](slice S, fn func(I) bool) {
+	for _, item := range slice {
+		if !fn(item) {
+			return
+		}
+	}
+}
+
 // ParallelForEach applies a given function to each element of the input slice in parallel.
 // The number of parallel workers is determined by the minimum of the number of CPU cores
 // and the length of the input slice.
@@ -313,39 +911,125 @@ func ParallelForEach[I any, S ~[]I](inputSlice S, forEachFunc func(I)) {
 	wg.Wait()
 }
 
-// Find searches for an element in the inputSlice that satisfies the given findFunc.
-// It returns the first element that matches the condition or the zero value of type T if no match is found.
-func Find[I any, S ~[]I](inputSlice S, findFunc func(I) bool) (foundItem I, didFind bool) {
-	for _, input := range inputSlice {
-		if findFunc(input) {
-			return input, true
-		}
+// ParallelForEachContext works like ParallelForEach but with a callback that can fail and
+// a context that can abort the whole run. It stops dispatching elements as soon as the context
+// is cancelled or any callback returns an error, waits for the callbacks already running,
+// and returns whichever of the first callback error or ctx.Err() happened first.
+// The context passed to the callbacks is cancelled when the run is aborted.
+func ParallelForEachContext[I any, S ~[]I](ctx context.Context, inputSlice S, fn func(context.Context, I) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	numWorkers := runtime.NumCPU()
+	if len(inputSlice) < numWorkers {
+		numWorkers = len(inputSlice)
 	}
-	var zero I
-	return zero, false
-}
 
-// SafeFind iterates over the elements of the input slice and applies the provided
-// findFunc to each element to determine if it matches a specific condition. If a match
-// is found, the function returns the matching item, a boolean indicating success, and nil
-// for the error. If no match is found, it returns the zero value of the item type, false,
-// and nil for the error. If an error occurs during the execution of findFunc, the function
-// immediately returns the zero value of the item type, false, and the encountered error.
-func SafeFind[I any, S ~[]I](inputSlice S, findFunc func(I) (bool, error)) (foundItem I, didFind bool, err error) {
-	for _, input := range inputSlice {
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
 
-		didFind, err := SafeExcecute(func() (out bool, errAux error) {
-			out, errAux = findFunc(input)
-			return
+	setErr := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
 		})
+	}
 
-		if err != nil {
-			return foundItem, false, err
-		}
+	inputChan := make(chan I)
 
-		if didFind {
-			return input, true, nil
-		}
+	// Start workers
+	for i := 0; i < numWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for input := range inputChan {
+				if err := fn(ctx, input); err != nil {
+					setErr(err)
+				}
+			}
+		}()
+	}
+
+	// Send input to workers until done or aborted
+dispatch:
+	for _, input := range inputSlice {
+		if err := ctx.Err(); err != nil {
+			setErr(err)
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			setErr(ctx.Err())
+			break dispatch
+		case inputChan <- input:
+		}
+	}
+	close(inputChan)
+
+	wg.Wait()
+
+	return firstErr
+}
+
+// ParallelForEachErr works like ParallelForEach but with a callback that can fail.
+// It stops dispatching elements as soon as a callback returns an error and returns the first error produced.
+// Panics in the callback are recovered and returned as errors, like SafeExcecute does.
+func ParallelForEachErr[I any, S ~[]I](inputSlice S, fn func(I) error) error {
+	return ParallelForEachContext(context.Background(), inputSlice, func(_ context.Context, input I) error {
+		_, err := SafeExcecute(func() (struct{}, error) {
+			return struct{}{}, fn(input)
+		})
+		return err
+	})
+}
+
+// Find searches for an element in the inputSlice that satisfies the given findFunc.
+// It returns the first element that matches the condition or the zero value of type T if no match is found.
+func Find[I any, S ~[]I](inputSlice S, findFunc func(I) bool) (foundItem I, didFind bool) {
+	for _, input := range inputSlice {
+		if findFunc(input) {
+			return input, true
+		}
+	}
+	var zero I
+	return zero, false
+}
+
+// FindLast searches the slice backwards for the last element that satisfies the given findFunc.
+// It returns that element or the zero value of type T if no match is found.
+func FindLast[I any, S ~[]I](slice S, findFunc func(I) bool) (item I, didFind bool) {
+	for i := len(slice) - 1; i >= 0; i-- {
+		if findFunc(slice[i]) {
+			return slice[i], true
+		}
+	}
+	var zero I
+	return zero, false
+}
+
+// SafeFind iterates over the elements of the input slice and applies the provided
+// findFunc to each element to determine if it matches a specific condition. If a match
+// is found, the function returns the matching item, a boolean indicating success, and nil
+// for the error. If no match is found, it returns the zero value of the item type, false,
+// and nil for the error. If an error occurs during the execution of findFunc, the function
+// immediately returns the zero value of the item type, false, and the encountered error.
+func SafeFind[I any, S ~[]I](inputSlice S, findFunc func(I) (bool, error)) (foundItem I, didFind bool, err error) {
+	for _, input := range inputSlice {
+
+		didFind, err := SafeExcecute(func() (out bool, errAux error) {
+			out, errAux = findFunc(input)
+			return
+		})
+
+		if err != nil {
+			return foundItem, false, err
+		}
+
+		if didFind {
+			return input, true, nil
+		}
 	}
 	return foundItem, false, nil
 }
@@ -361,6 +1045,73 @@ func FindIndex[I any, S ~[]I](inputSlice S, findFunc func(I) bool) int {
 	return -1
 }
 
+// FindLastIndex returns the index of the last element in the slice that satisfies the predicate,
+// searching from the end. If no element satisfies the condition, it returns -1.
+func FindLastIndex[I any, S ~[]I](slice S, predicate func(I) bool) int {
+	for i := len(slice) - 1; i >= 0; i-- {
+		if predicate(slice[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// IndexOf returns the index of the first occurrence of element in the slice.
+// If the element is not present, it returns -1.
+func IndexOf[I comparable, S ~[]I](slice S, element I) int {
+	for i, e := range slice {
+		if e == element {
+			return i
+		}
+	}
+	return -1
+}
+
+// LastIndexOf returns the index of the last occurrence of element in the slice, searching from the end.
+// If the element is not present, it returns -1.
+func LastIndexOf[I comparable, S ~[]I](slice S, element I) int {
+	for i := len(slice) - 1; i >= 0; i-- {
+		if slice[i] == element {
+			return i
+		}
+	}
+	return -1
+}
+
+// IndexOfSubslice returns the index of the first occurrence of needle as a contiguous
+// subslice of haystack, or -1 if it is not present. An empty needle is found at index 0.
+func IndexOfSubslice[I comparable, S ~[]I](haystack, needle S) int {
+	for i := 0; i+len(needle) <= len(haystack); i++ {
+		if Compare(haystack[i:i+len(needle)], needle) {
+			return i
+		}
+	}
+	return -1
+}
+
+// BinarySearch searches for target in a slice sorted in ascending order in O(log n),
+// returning the index of its first occurrence and true if it is present, or the index where
+// it would be inserted to keep the slice sorted and false otherwise.
+// The slice must be sorted in ascending order, otherwise the result is meaningless.
+func BinarySearch[I cmp.Ordered, S ~[]I](slice S, target I) (index int, found bool) {
+	index = sort.Search(len(slice), func(i int) bool {
+		return slice[i] >= target
+	})
+	return index, index < len(slice) && slice[index] == target
+}
+
+// BinarySearchFunc works like BinarySearch for slices sorted by a projected key.
+// cmp must return a negative number if the element comes before the sought value, zero if it
+// matches and a positive number if it comes after it. It returns the index of the first match and true,
+// or the index where the sought value would be inserted and false if there is no match.
+// The slice must be sorted consistently with cmp, otherwise the result is meaningless.
+func BinarySearchFunc[I any, S ~[]I](slice S, cmp func(I) int) (index int, found bool) {
+	index = sort.Search(len(slice), func(i int) bool {
+		return cmp(slice[i]) >= 0
+	})
+	return index, index < len(slice) && cmp(slice[index]) == 0
+}
+
 // Contains checks if the given element is present in the slice.
 // It returns true if the element is found, otherwise it returns false.
 func Contains[I comparable, S ~[]I](slice S, element I) bool {
@@ -372,6 +1123,13 @@ func Contains[I comparable, S ~[]I](slice S, element I) bool {
 	return false
 }
 
+// ContainsFunc checks if any element of the slice satisfies the given predicate function,
+// for elements that are not comparable or to match them partially.
+// It returns true on the first match, otherwise it returns false.
+func ContainsFunc[I any, S ~[]I](slice S, predicate func(I) bool) bool {
+	return Any(slice, predicate)
+}
+
 // All checks if all elements in the given slice satisfy the provided predicate function.
 // It returns true if all elements satisfy the predicate, otherwise it returns false.
 func All[I any, S ~[]I](slice S, predicate func(I) bool) bool {
@@ -394,6 +1152,67 @@ func Any[I any, S ~[]I](slice S, predicate func(I) bool) bool {
 	return false
 }
 
+// Count returns the number of elements in the slice that satisfy the given predicate function.
+func Count[I any, S ~[]I](slice S, predicate func(I) bool) int {
+	count := 0
+	for _, item := range slice {
+		if predicate(item) {
+			count++
+		}
+	}
+	return count
+}
+
+// Take returns the first n elements of the slice. n is clamped to the length of the slice
+// and a negative n is treated as 0.
+// The result is a sub-slice that shares the backing array of the input slice.
+func Take[I any, S ~[]I](slice S, n int) S {
+	if n < 0 {
+		n = 0
+	}
+	if n > len(slice) {
+		n = len(slice)
+	}
+	return slice[:n]
+}
+
+// TakeWhile returns the leading run of elements of the slice that satisfy the predicate,
+// stopping at the first element that doesn't.
+// Like Take, the result is a sub-slice that shares the backing array of the input slice.
+func TakeWhile[I any, S ~[]I](slice S, predicate func(I) bool) S {
+	for i, item := range slice {
+		if !predicate(item) {
+			return slice[:i]
+		}
+	}
+	return slice
+}
+
+// Drop returns the slice without its first n elements. Dropping more elements than
+// the length of the slice returns an empty slice and a negative n is treated as 0.
+// The result is a sub-slice that shares the backing array of the input slice.
+func Drop[I any, S ~[]I](slice S, n int) S {
+	if n < 0 {
+		n = 0
+	}
+	if n > len(slice) {
+		n = len(slice)
+	}
+	return slice[n:]
+}
+
+// DropWhile returns the slice without the leading run of elements that satisfy the predicate,
+// starting at the first element that doesn't.
+// Like Drop, the result is a sub-slice that shares the backing array of the input slice.
+func DropWhile[I any, S ~[]I](slice S, predicate func(I) bool) S {
+	for i, item := range slice {
+		if !predicate(item) {
+			return slice[i:]
+		}
+	}
+	return slice[len(slice):]
+}
+
 // Chunk splits a slice into multiple smaller slices (chunks) of a specified size.
 // If the chunkSize is less than or equal to 0, or if the input slice is empty,
 // it returns an empty slice of slices.
@@ -428,6 +1247,118 @@ func Chunk[I any, S ~[]I](slice S, chunkSize int) []S {
 	return chunks
 }
 
+// ChunkStep splits a slice into chunks of the given size, starting a new chunk every step elements.
+// A step smaller than size yields overlapping chunks and a step bigger than size skips elements,
+// e.g. ChunkStep([1, 2, 3, 4, 5], 2, 1) returns [[1, 2], [2, 3], [3, 4], [4, 5]].
+// Chunking stops with the first chunk that reaches the end of the slice, which may be shorter than size,
+// so ChunkStep(slice, n, n) is equivalent to Chunk(slice, n).
+// If size or step are less than or equal to 0, or if the input slice is empty, it returns an empty slice of slices.
+// Every chunk is a sub-slice that shares the backing array of the input slice.
+func ChunkStep[I any, S ~[]I](slice S, size, step int) []S {
+	if size <= 0 || step <= 0 || len(slice) == 0 {
+		return []S{}
+	}
+
+	chunks := make([]S, 0)
+	for i := 0; i < len(slice); i += step {
+		end := i + size
+		if end > len(slice) {
+			end = len(slice)
+		}
+		chunks = append(chunks, slice[i:end])
+
+		if end == len(slice) {
+			break
+		}
+	}
+
+	return chunks
+}
+
+// ChunkBy splits a slice into chunks of consecutive elements, starting a new chunk
+// whenever boundary returns true for a pair of adjacent elements.
+// The first element always begins the first chunk. If the input slice is empty,
+// it returns an empty slice of slices.
+// Every chunk is a sub-slice that shares the backing array of the input slice.
+func ChunkBy[I any, S ~[]I](slice S, boundary func(prev, curr I) bool) []S {
+	if len(slice) == 0 {
+		return []S{}
+	}
+
+	chunks := make([]S, 0)
+	start := 0
+	for i := 1; i < len(slice); i++ {
+		if boundary(slice[i-1], slice[i]) {
+			chunks = append(chunks, slice[start:i])
+			start = i
+		}
+	}
+
+	return append(chunks, slice[start:])
+}
+
+// Window returns all the contiguous windows of the given size over the slice,
+// e.g. Window([1, 2, 3, 4], 2) returns [[1, 2], [2, 3], [3, 4]].
+// If size is less than or equal to 0 or bigger than the length of the slice, it returns an empty slice of slices.
+// Every window is a sub-slice that shares the backing array of the input slice.
+func Window[I any, S ~[]I](slice S, size int) []S {
+	if size <= 0 || size > len(slice) {
+		return []S{}
+	}
+
+	windows := make([]S, 0, len(slice)-size+1)
+	for i := 0; i+size <= len(slice); i++ {
+		windows = append(windows, slice[i:i+size])
+	}
+
+	return windows
+}
+
+// CircularWindow works like Window but treats the slice as cyclic,
+// so that the windows wrap around the end back to the start,
+// e.g. CircularWindow([1, 2, 3], 2) returns [[1, 2], [2, 3], [3, 1]].
+// If size is less than or equal to 0 or bigger than the length of the slice, it returns an empty slice of slices.
+//
+// Windows that fit in a contiguous region share the backing array of the input slice,
+// while windows that wrap around are freshly allocated since they can't alias it.
+func CircularWindow[I any, S ~[]I](slice S, size int) []S {
+	if size <= 0 || size > len(slice) {
+		return []S{}
+	}
+
+	windows := make([]S, 0, len(slice))
+	for i := range slice {
+		end := i + size
+		if end <= len(slice) {
+			windows = append(windows, slice[i:end])
+			continue
+		}
+
+		window := make(S, 0, size)
+		window = append(window, slice[i:]...)
+		window = append(window, slice[:end-len(slice)]...)
+		windows = append(windows, window)
+	}
+
+	return windows
+}
+
+// OptimalChunkSize returns the chunk size that spreads sliceLen elements as evenly as possible
+// across the given number of workers, so that Chunk can be combined with the parallel helpers
+// without guessing. It is the ceiling of sliceLen/workers and never less than 1.
+// If workers is less than or equal to 0, the number of available CPU cores is used.
+func OptimalChunkSize(sliceLen, workers int) int {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	chunkSize := (sliceLen + workers - 1) / workers
+	if chunkSize < 1 {
+		return 1
+	}
+	return chunkSize
+}
+
 // Compare takes two slices of any comparable type and returns true if they are equal.
 // Two slices are considered equal if they have the same length and all corresponding
 // elements are equal.
@@ -445,6 +1376,77 @@ func Compare[I comparable, S ~[]I](a, b S) bool {
 	return true
 }
 
+// CompareFunc works like Compare but checks every pair of corresponding elements with eq,
+// e.g. to compare structs with a float tolerance or on a subset of their fields.
+//
+// Returns true if the slices have the same length and every pair satisfies eq, false otherwise.
+func CompareFunc[I any, S ~[]I](a, b S, eq func(x, y I) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !eq(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// CommonPrefixLength returns the number of leading elements shared by a and b.
+// It returns 0 if either slice is empty.
+func CommonPrefixLength[I comparable, S ~[]I](a, b S) int {
+	length := 0
+	for length < len(a) && length < len(b) && a[length] == b[length] {
+		length++
+	}
+	return length
+}
+
+// CommonPrefix returns a new slice containing the leading elements shared by a and b.
+// It returns an empty slice if the slices have no common prefix.
+func CommonPrefix[I comparable, S ~[]I](a, b S) S {
+	length := CommonPrefixLength(a, b)
+
+	prefix := make(S, length)
+	copy(prefix, a[:length])
+
+	return prefix
+}
+
+// CommonSuffixLength returns the number of trailing elements shared by a and b.
+// It returns 0 if either slice is empty.
+func CommonSuffixLength[I comparable, S ~[]I](a, b S) int {
+	length := 0
+	for length < len(a) && length < len(b) && a[len(a)-length-1] == b[len(b)-length-1] {
+		length++
+	}
+	return length
+}
+
+// EqualUnorderedBy reports whether a and b contain the same multiset of elements,
+// as identified by the key returned by keyFunc, regardless of their order.
+// It is meant for comparing slices of non-comparable elements, e.g. structs by their id.
+func EqualUnorderedBy[I any, K comparable, S ~[]I](a, b S, keyFunc func(I) K) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	frequencies := make(map[K]int, len(a))
+	for _, item := range a {
+		frequencies[keyFunc(item)]++
+	}
+
+	for _, item := range b {
+		key := keyFunc(item)
+		if frequencies[key] == 0 {
+			return false
+		}
+		frequencies[key]--
+	}
+
+	return true
+}
+
 // Distinct returns a new slice containing only the distinct elements from the input slice.
 // The order of elements in the result slice is the same as their first occurrence in the input slice.
 func Distinct[I comparable, S ~[]I](slice S) S {
@@ -463,6 +1465,35 @@ func Distinct[I comparable, S ~[]I](slice S) S {
 	return slice[:newSliceLen]
 }
 
+// Dedup collapses consecutive runs of equal elements into a single element, like Unix uniq,
+// e.g. [1, 1, 2, 2, 1] becomes [1, 2, 1]. Unlike Distinct, non-adjacent duplicates are kept.
+// Like Distinct, it reuses the backing array of the input slice.
+func Dedup[I comparable, S ~[]I](slice S) S {
+	return DedupFunc(slice, func(a, b I) bool {
+		return a == b
+	})
+}
+
+// DedupFunc works like Dedup but uses eq to decide whether two adjacent elements are equal,
+// so it can be used with elements that are not comparable.
+// Like Distinct, it reuses the backing array of the input slice.
+func DedupFunc[I any, S ~[]I](slice S, eq func(a, b I) bool) S {
+	if len(slice) == 0 {
+		return slice
+	}
+
+	newSliceLen := 1
+	for _, item := range slice[1:] {
+		if eq(slice[newSliceLen-1], item) {
+			continue
+		}
+		slice[newSliceLen] = item
+		newSliceLen++
+	}
+
+	return slice[:newSliceLen]
+}
+
 type identifiable[T any] interface {
 	Id() T
 }
@@ -492,6 +1523,7 @@ func UniqueItemsById[Id comparable, I identifiable[Id], S ~[]I](slice S) S {
 // Intersection returns the common elements between two slices.
 // It takes two slices of any comparable type and returns a slice containing
 // the elements that are present in both input slices.
+// Each common element appears only once, in the order of its first appearance in b.
 func Intersection[I comparable, S ~[]I](a, b S) S {
 	set := make(map[I]struct{})
 	for _, item := range a {
@@ -502,6 +1534,8 @@ func Intersection[I comparable, S ~[]I](a, b S) S {
 	for _, item := range b {
 		if _, ok := set[item]; ok {
 			result = append(result, item)
+			// Forget the element so that duplicates in b are not added again
+			delete(set, item)
 		}
 	}
 
@@ -551,6 +1585,9 @@ func Union[I comparable, S ~[]I](a, b S) S {
 // Returns:
 //
 //	A slice containing the elements that are in `a` but not in `b`.
+//
+// The result reuses the backing array of `a`, so the original contents of `a` are overwritten.
+// Use DifferenceCopy to leave both inputs untouched.
 func Difference[I comparable, S ~[]I](a, b S) S {
 	set := make(map[I]struct{})
 	for _, item := range b {
@@ -569,3 +1606,552 @@ func Difference[I comparable, S ~[]I](a, b S) S {
 
 	return a[:newSliceLen]
 }
+
+// GroupBy groups the elements of the slice by the key returned by keyFunc,
+// preserving the input order inside each group.
+func GroupBy[I any, K comparable, S ~[]I](slice S, keyFunc func(I) K) map[K]S {
+	groups := make(map[K]S)
+
+	for _, item := range slice {
+		key := keyFunc(item)
+		groups[key] = append(groups[key], item)
+	}
+
+	return groups
+}
+
+// GroupByOrdered works like GroupBy but also returns the keys in the order in which they
+// first appear in the slice, so that the groups can be walked deterministically.
+func GroupByOrdered[I any, K comparable, S ~[]I](slice S, keyFunc func(I) K) (keys []K, groups map[K]S) {
+	keys = make([]K, 0)
+	groups = make(map[K]S)
+
+	for _, item := range slice {
+		key := keyFunc(item)
+		if _, seen := groups[key]; !seen {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], item)
+	}
+
+	return keys, groups
+}
+
+// KeyBy builds a lookup map from the key returned by keyFunc to the element of the slice.
+// If several elements share the same key, later elements overwrite earlier ones.
+func KeyBy[I any, K comparable, S ~[]I](slice S, keyFunc func(I) K) map[K]I {
+	result := make(map[K]I, len(slice))
+
+	for _, item := range slice {
+		result[keyFunc(item)] = item
+	}
+
+	return result
+}
+
+// Associate builds a map from the key/value pairs returned by fn for every element of the slice.
+// If several elements produce the same key, later elements overwrite earlier ones.
+// It returns an empty map for an empty slice.
+func Associate[I any, K comparable, V any, S ~[]I](slice S, fn func(I) (K, V)) map[K]V {
+	result := make(map[K]V, len(slice))
+
+	for _, item := range slice {
+		key, value := fn(item)
+		result[key] = value
+	}
+
+	return result
+}
+
+// CountBy returns how many elements of the slice fall into each key returned by keyFunc,
+// without materializing the groups. It returns an empty map for an empty slice.
+func CountBy[I any, K comparable, S ~[]I](slice S, keyFunc func(I) K) map[K]int {
+	counts := make(map[K]int)
+
+	for _, item := range slice {
+		counts[keyFunc(item)]++
+	}
+
+	return counts
+}
+
+// GroupByReduce groups the elements of the slice by the key returned by keyFunc and folds
+// each group into a single value using reduceFunc, all in a single pass.
+// The per-group slices are never materialized, only the running accumulator of each key.
+// Every group starts its fold from a copy of initialValue. The copy is shallow, so a
+// reference-typed initialValue (a slice with spare capacity, a map or a pointer) is shared
+// by all the groups; use a nil slice or a zero value for such accumulators.
+func GroupByReduce[I any, K comparable, O any, S ~[]I](slice S, keyFunc func(I) K, reduceFunc func(O, I) O, initialValue O) map[K]O {
+	result := make(map[K]O)
+
+	for _, item := range slice {
+		key := keyFunc(item)
+		accumulator, ok := result[key]
+		if !ok {
+			accumulator = initialValue
+		}
+		result[key] = reduceFunc(accumulator, item)
+	}
+
+	return result
+}
+
+// GroupByStringer is GroupBy specialized to string keys,
+// preserving the input order inside each group.
+// It is meant for composite keys built by formatting several fields,
+// e.g. fmt.Sprintf("%d-%s", a, b), without having to declare a comparable struct key.
+func GroupByStringer[I any, S ~[]I](slice S, keyFunc func(I) string) map[string]S {
+	return GroupBy(slice, keyFunc)
+}
+
+// WeightedSample picks n elements of the slice without replacement, where the probability
+// of each element being picked is proportional to the weight returned by weightFunc.
+// It uses the single-pass A-Res weighted reservoir algorithm, running in O(len(slice) log n).
+// The random source is injected through r so that results are reproducible in tests.
+//
+// Elements with a weight of 0 are never picked, so the result may contain fewer than n elements.
+// It panics with "WeightedSample: negative or NaN weight" if weightFunc returns a negative value or NaN.
+// The order of the returned elements is unspecified.
+func WeightedSample[I any, S ~[]I](slice S, n int, weightFunc func(I) float64, r *rand.Rand) S {
+	if n <= 0 {
+		return S{}
+	}
+
+	type weightedItem struct {
+		item I
+		key  float64
+	}
+
+	reservoir := &funcHeap[weightedItem]{
+		less: func(a, b weightedItem) bool {
+			return a.key < b.key
+		},
+	}
+
+	for _, item := range slice {
+		weight := weightFunc(item)
+		// NaN compares false both ways, so it is rejected along with negative weights
+		if !(weight >= 0) {
+			panic("WeightedSample: negative or NaN weight")
+		}
+		if weight == 0 {
+			continue
+		}
+
+		key := math.Pow(r.Float64(), 1/weight)
+		if reservoir.Len() < n {
+			heap.Push(reservoir, weightedItem{item: item, key: key})
+			continue
+		}
+		if key > reservoir.items[0].key {
+			reservoir.items[0] = weightedItem{item: item, key: key}
+			heap.Fix(reservoir, 0)
+		}
+	}
+
+	result := make(S, len(reservoir.items))
+	for i, weighted := range reservoir.items {
+		result[i] = weighted.item
+	}
+
+	return result
+}
+
+// DrainChan collects a batch of up to max items from the channel into a slice.
+// It blocks until the first item is received and then greedily takes whatever other
+// items are immediately available, without waiting for more.
+// It returns an empty slice if max is less than or equal to 0 or if the channel is closed and drained.
+func DrainChan[I any](ch <-chan I, max int) []I {
+	if max <= 0 {
+		return []I{}
+	}
+
+	first, ok := <-ch
+	if !ok {
+		return []I{}
+	}
+
+	batch := []I{first}
+	for len(batch) < max {
+		select {
+		case item, ok := <-ch:
+			if !ok {
+				return batch
+			}
+			batch = append(batch, item)
+		default:
+			return batch
+		}
+	}
+
+	return batch
+}
+
+// GroupBySorted groups the elements of the slice by the key returned by keyFunc
+// and sorts every group with the provided less function.
+// It returns an empty map for an empty slice.
+func GroupBySorted[I any, K comparable, S ~[]I](slice S, keyFunc func(I) K, less func(a, b I) bool) map[K]S {
+	groups := GroupBy(slice, keyFunc)
+
+	for _, group := range groups {
+		Sort(group, less)
+	}
+
+	return groups
+}
+
+// FlattenDeep recursively flattens arbitrarily nested slices or arrays, such as the []interface{}
+// trees produced by decoding JSON, into a flat slice of the elements of type I.
+// maxDepth is the number of nesting levels that are descended into, starting with value itself;
+// a negative maxDepth means there is no limit.
+//
+// Slices and arrays are descended into before checking their type, so when I is an interface
+// that slices satisfy, such as any, the leaves are collected rather than the slices themselves.
+// Slices nested deeper than maxDepth are treated as leaves. Leaves that are not of type I
+// are silently skipped. It relies on reflection, so prefer the type-safe helpers when the
+// nesting is known at compile time.
+func FlattenDeep[I any](value any, maxDepth int) []I {
+	result := make([]I, 0)
+	return flattenDeep(reflect.ValueOf(value), maxDepth, result)
+}
+
+func flattenDeep[I any](value reflect.Value, depth int, result []I) []I {
+	if !value.IsValid() {
+		return result
+	}
+
+	if value.Kind() == reflect.Interface {
+		return flattenDeep(value.Elem(), depth, result)
+	}
+
+	if (value.Kind() == reflect.Slice || value.Kind() == reflect.Array) && depth != 0 {
+		for i := 0; i < value.Len(); i++ {
+			result = flattenDeep(value.Index(i), depth-1, result)
+		}
+		return result
+	}
+
+	if item, ok := value.Interface().(I); ok {
+		return append(result, item)
+	}
+
+	return result
+}
+
+// MergeJoin walks two slices sorted by key in lockstep and calls onMatch for every pair of
+// elements sharing the same key, like a SQL inner join, in O(len(a) + len(b)) plus the number of matches.
+// Both a and b must be sorted ascending by keyA and keyB respectively, otherwise matches are missed.
+//
+// When a key is repeated on either side, onMatch is called for the cross product of the
+// matching runs, in the order the elements appear in a and then in b.
+func MergeJoin[A any, B any, K cmp.Ordered](a []A, b []B, keyA func(A) K, keyB func(B) K, onMatch func(A, B)) {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		currentKeyA := keyA(a[i])
+		currentKeyB := keyB(b[j])
+
+		if currentKeyA < currentKeyB {
+			i++
+			continue
+		}
+		if currentKeyA > currentKeyB {
+			j++
+			continue
+		}
+
+		// Find the end of the run sharing the key on both sides
+		endA := i + 1
+		for endA < len(a) && keyA(a[endA]) == currentKeyA {
+			endA++
+		}
+		endB := j + 1
+		for endB < len(b) && keyB(b[endB]) == currentKeyB {
+			endB++
+		}
+
+		for _, itemA := range a[i:endA] {
+			for _, itemB := range b[j:endB] {
+				onMatch(itemA, itemB)
+			}
+		}
+
+		i, j = endA, endB
+	}
+}
+
+// Pair holds two values of possibly different types, as produced by Zip.
+type Pair[A any, B any] struct {
+	First  A
+	Second B
+}
+
+// Zip pairs the elements of a and b by position, stopping at the shorter of the two slices.
+//
+// Example usage:
+//
+//	pairs := Zip([]int{1, 2, 3}, []string{"a", "b"})
+//	// pairs == []Pair[int, string]{{1, "a"}, {2, "b"}}
+func Zip[A any, B any](a []A, b []B) []Pair[A, B] {
+	length := len(a)
+	if len(b) < length {
+		length = len(b)
+	}
+
+	pairs := make([]Pair[A, B], length)
+	for i := range pairs {
+		pairs[i] = Pair[A, B]{First: a[i], Second: b[i]}
+	}
+
+	return pairs
+}
+
+// Unzip splits a slice of pairs into two slices of equal length holding
+// the First and Second fields respectively. It is the inverse of Zip.
+func Unzip[A any, B any](pairs []Pair[A, B]) ([]A, []B) {
+	firsts := make([]A, len(pairs))
+	seconds := make([]B, len(pairs))
+
+	for i, pair := range pairs {
+		firsts[i] = pair.First
+		seconds[i] = pair.Second
+	}
+
+	return firsts, seconds
+}
+
+// Fill sets every element of the slice to value in place and returns the slice.
+func Fill[I any, S ~[]I](slice S, value I) S {
+	for i := range slice {
+		slice[i] = value
+	}
+	return slice
+}
+
+// Repeat returns a newly allocated slice holding count copies of value.
+// A negative count returns an empty slice.
+func Repeat[I any](value I, count int) []I {
+	if count < 0 {
+		count = 0
+	}
+	return Fill(make([]I, count), value)
+}
+
+// Range returns the integers from start up to, but excluding, end, advancing by step:
+// [start, start+step, start+2*step, ...). A negative step produces a descending range.
+// It returns an empty slice when end can't be reached from start with the given step.
+// It panics with "Range: step cannot be zero" if step is 0.
+func Range(start, end, step int) []int {
+	if step == 0 {
+		panic("Range: step cannot be zero")
+	}
+
+	// The distance and the step are computed as unsigned values, so the element count
+	// is exact even for ranges spanning the whole int domain, where start+step would overflow
+	var distance, stride uint
+	if step > 0 {
+		if start >= end {
+			return []int{}
+		}
+		distance, stride = uint(end)-uint(start), uint(step)
+	} else {
+		if start <= end {
+			return []int{}
+		}
+		distance, stride = uint(start)-uint(end), uint(0)-uint(step)
+	}
+
+	result := make([]int, (distance-1)/stride+1)
+	for i := range result {
+		result[i] = start + i*step
+	}
+
+	return result
+}
+
+// InsertAt returns a new slice with the given elements inserted at index, shifting the elements
+// from index onwards to the right. index may be equal to the length of the slice to append the elements.
+// The input slice is not modified.
+// It returns an error wrapping ErrIndexOutOfRange if index is negative or bigger than the length of the slice.
+func InsertAt[I any, S ~[]I](slice S, index int, elements ...I) (S, error) {
+	if index < 0 || index > len(slice) {
+		return slice, fmt.Errorf("InsertAt: %w: index %d with length %d", ErrIndexOutOfRange, index, len(slice))
+	}
+
+	result := make(S, len(slice)+len(elements))
+	copy(result, slice[:index])
+	copy(result[index:], elements)
+	copy(result[index+len(elements):], slice[index:])
+
+	return result, nil
+}
+
+// RemoveAt removes the element at index, shifting the following elements to the left
+// so that the order of the remaining elements is preserved.
+// Like RemoveElement, it reuses the backing array of the input slice.
+// It returns an error wrapping ErrIndexOutOfRange if index is outside of the slice.
+func RemoveAt[I any, S ~[]I](slice S, index int) (S, error) {
+	if index < 0 || index >= len(slice) {
+		return slice, fmt.Errorf("RemoveAt: %w: index %d with length %d", ErrIndexOutOfRange, index, len(slice))
+	}
+
+	return RemoveRange(slice, index, index+1)
+}
+
+// RemoveRange removes the elements from start up to, but excluding, end, shifting the following
+// elements to the left so that the order of the remaining elements is preserved.
+// Like RemoveElement, it reuses the backing array of the input slice.
+// It returns an error wrapping ErrIndexOutOfRange if start and end don't describe a valid range of the slice.
+func RemoveRange[I any, S ~[]I](slice S, start, end int) (S, error) {
+	if start < 0 || end > len(slice) || start > end {
+		return slice, fmt.Errorf("RemoveRange: %w: range [%d, %d) with length %d", ErrIndexOutOfRange, start, end, len(slice))
+	}
+
+	newSliceLen := start + copy(slice[start:], slice[end:])
+
+	return slice[:newSliceLen], nil
+}
+
+// Sample returns n distinct elements of the slice picked at random without replacement,
+// using reservoir sampling so that the slice is traversed only once.
+// Requesting more elements than the length of the slice returns a shuffled copy of all of them.
+// The random source is injected through r so that results are reproducible in tests.
+// The input slice is not modified.
+func Sample[I any, S ~[]I](slice S, n int, r *rand.Rand) S {
+	if n <= 0 {
+		return S{}
+	}
+	if n > len(slice) {
+		n = len(slice)
+	}
+
+	reservoir := make(S, n)
+	copy(reservoir, slice[:n])
+
+	for i := n; i < len(slice); i++ {
+		if j := r.Intn(i + 1); j < n {
+			reservoir[j] = slice[i]
+		}
+	}
+
+	r.Shuffle(len(reservoir), func(i, j int) {
+		reservoir[i], reservoir[j] = reservoir[j], reservoir[i]
+	})
+
+	return reservoir
+}
+
+// DifferenceCopy works like Difference, returning the elements in `a` that are not in `b`,
+// but the result is newly allocated and both inputs are left untouched.
+func DifferenceCopy[I comparable, S ~[]I](a, b S) S {
+	set := make(map[I]struct{})
+	for _, item := range b {
+		set[item] = struct{}{}
+	}
+
+	result := make(S, 0)
+	for _, item := range a {
+		if _, exists := set[item]; exists {
+			continue
+		}
+		result = append(result, item)
+	}
+
+	return result
+}
+
+// IntersectionBy works like Intersection but compares elements by the key returned by keyFunc,
+// so it can be used with elements that are not comparable themselves.
+// The returned elements are taken from b, each key appearing only once, in the order of its first appearance in b.
+// The inputs are not modified.
+func IntersectionBy[I any, K comparable, S ~[]I](a, b S, keyFunc func(I) K) S {
+	keys := make(map[K]struct{})
+	for _, item := range a {
+		keys[keyFunc(item)] = struct{}{}
+	}
+
+	result := make(S, 0)
+	for _, item := range b {
+		key := keyFunc(item)
+		if _, ok := keys[key]; ok {
+			result = append(result, item)
+			delete(keys, key)
+		}
+	}
+
+	return result
+}
+
+// DifferenceBy works like Difference but compares elements by the key returned by keyFunc,
+// so it can be used with elements that are not comparable themselves.
+// The returned elements are taken from a, keeping their order. The inputs are not modified.
+func DifferenceBy[I any, K comparable, S ~[]I](a, b S, keyFunc func(I) K) S {
+	keys := make(map[K]struct{})
+	for _, item := range b {
+		keys[keyFunc(item)] = struct{}{}
+	}
+
+	result := make(S, 0)
+	for _, item := range a {
+		if _, exists := keys[keyFunc(item)]; exists {
+			continue
+		}
+		result = append(result, item)
+	}
+
+	return result
+}
+
+// UnionBy works like Union but compares elements by the key returned by keyFunc,
+// so it can be used with elements that are not comparable themselves.
+// When several elements share a key, the first one found is kept, looking at a before b,
+// and the result follows that order of first appearance. The inputs are not modified.
+func UnionBy[I any, K comparable, S ~[]I](a, b S, keyFunc func(I) K) S {
+	seen := make(map[K]struct{})
+
+	result := make(S, 0)
+	for _, slice := range []S{a, b} {
+		for _, item := range slice {
+			key := keyFunc(item)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+// SymmetricDifference returns the elements that are present in exactly one of the two slices.
+// Each element appears only once, following the order of first appearance in a and then in b.
+// The inputs are not modified.
+func SymmetricDifference[I comparable, S ~[]I](a, b S) S {
+	inA := make(map[I]struct{}, len(a))
+	for _, item := range a {
+		inA[item] = struct{}{}
+	}
+	inB := make(map[I]struct{}, len(b))
+	for _, item := range b {
+		inB[item] = struct{}{}
+	}
+
+	result := make(S, 0)
+	for _, item := range a {
+		if _, ok := inB[item]; ok {
+			continue
+		}
+		result = append(result, item)
+		// Mark the element as added so that duplicates in a are skipped
+		inB[item] = struct{}{}
+	}
+	for _, item := range b {
+		if _, ok := inA[item]; ok {
+			continue
+		}
+		result = append(result, item)
+		inA[item] = struct{}{}
+	}
+
+	return result
+}
@@ -5,8 +5,11 @@ package slicesutils
 
 import (
 	"cmp"
+	"container/heap"
 	"iter"
 	"math"
+	"runtime"
+	"sync"
 )
 
 func MaxSeq[I cmp.Ordered](inputSeq iter.Seq[I]) I {
@@ -43,6 +46,34 @@ func MaxSeqFunc[I any](inputSeq iter.Seq[I], maxFunc func(I, I) I) I {
 	return mx
 }
 
+// MinSeq returns the minimum value of the sequence.
+// It panics with "MinSeq: empty sequence" if the sequence is empty.
+func MinSeq[I cmp.Ordered](inputSeq iter.Seq[I]) I {
+	return MinSeqFunc(inputSeq, func(a, b I) I {
+		return min(a, b)
+	})
+}
+
+// MinSeqFunc returns the minimum value of the sequence, as determined by minFunc, which
+// receives the current minimum and the next element and returns the smaller of both.
+// It panics with "MinSeq: empty sequence" if the sequence is empty.
+func MinSeqFunc[I any](inputSeq iter.Seq[I], minFunc func(I, I) I) I {
+	next, stop := iter.Pull(inputSeq)
+
+	defer stop()
+
+	first, ok := next()
+	if !ok {
+		panic("MinSeq: empty sequence")
+	}
+	mn := first
+	for nextItem, ok := next(); ok; nextItem, ok = next() {
+		mn = minFunc(mn, nextItem)
+	}
+
+	return mn
+}
+
 func MapSeq[I any, O any](inputSeq iter.Seq[I], mapFunc func(I) O) iter.Seq[O] {
 	return func(yield func(O) bool) {
 		for input := range inputSeq {
@@ -53,6 +84,8 @@ func MapSeq[I any, O any](inputSeq iter.Seq[I], mapFunc func(I) O) iter.Seq[O] {
 	}
 }
 
+// SafeMapSeq lazily applies mapFunc to each element of the sequence, recovering from panics.
+// The sequence silently stops at the first error, use SafeMapSeqErr to find out whether it failed.
 func SafeMapSeq[I any, O any](inputSeq iter.Seq[I], mapFunc func(I) (O, error)) iter.Seq[O] {
 	return func(yield func(O) bool) {
 		for input := range inputSeq {
@@ -69,6 +102,34 @@ func SafeMapSeq[I any, O any](inputSeq iter.Seq[I], mapFunc func(I) (O, error))
 	}
 }
 
+// SafeMapSeqErr works like SafeMapSeq, but alongside the sequence it returns a function
+// that reports the error that stopped the iteration, if any.
+// The error function must be called after the iteration has completed; it returns nil
+// if the sequence ended normally or was stopped early by the consumer.
+func SafeMapSeqErr[I any, O any](inputSeq iter.Seq[I], mapFunc func(I) (O, error)) (iter.Seq[O], func() error) {
+	var err error
+
+	outputSeq := func(yield func(O) bool) {
+		err = nil
+		for input := range inputSeq {
+			out, errAux := SafeExcecute(func() (O, error) {
+				return mapFunc(input)
+			})
+			if errAux != nil {
+				err = errAux
+				return
+			}
+			if !yield(out) {
+				return
+			}
+		}
+	}
+
+	return outputSeq, func() error {
+		return err
+	}
+}
+
 func FilterSeq[I any](inputSeq iter.Seq[I], filterFunc func(I) bool) iter.Seq[I] {
 	return func(yield func(I) bool) {
 		for input := range inputSeq {
@@ -87,6 +148,166 @@ func ReduceSeq[I any, O any](inputSeq iter.Seq[I], reduceFunc func(O, I) O, init
 	return result
 }
 
+// ScanSeq lazily yields every successive accumulator value of folding the sequence with fn,
+// starting from initialValue, like a streaming Scan.
+func ScanSeq[I any, O any](inputSeq iter.Seq[I], fn func(O, I) O, initialValue O) iter.Seq[O] {
+	return func(yield func(O) bool) {
+		accumulator := initialValue
+		for input := range inputSeq {
+			accumulator = fn(accumulator, input)
+			if !yield(accumulator) {
+				return
+			}
+		}
+	}
+}
+
+// ParallelReduceSeq buffers the sequence into chunks of chunkSize elements, reduces every chunk
+// concurrently with reduceFunc starting from initialValue, and merges the partial results with combine
+// in the order of the chunks. At most as many chunks as CPU cores are reduced at the same time.
+//
+// combine must be associative and initialValue must be its identity (e.g. 0 for a sum),
+// since it is used as the starting accumulator of every chunk.
+// initialValue is copied by value into every chunk and those chunks are reduced concurrently,
+// so it must not be a slice with spare capacity, a map or a pointer: every chunk would write
+// into the same memory. Use a nil slice or a zero value instead.
+// A chunkSize less than or equal to 0 is treated as 1. It returns initialValue for an empty sequence.
+//
+// A panic in reduceFunc is recovered on the worker goroutine and raised again on the
+// caller's goroutine as a *PanicError once every started chunk has finished.
+func ParallelReduceSeq[I any, O any](inputSeq iter.Seq[I], chunkSize int, reduceFunc func(O, I) O, combine func(O, O) O, initialValue O) O {
+	if chunkSize <= 0 {
+		chunkSize = 1
+	}
+
+	next, stop := iter.Pull(inputSeq)
+	defer stop()
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var workerPanic *PanicError
+	workerSlots := make(chan struct{}, runtime.NumCPU())
+	var partials []*O
+
+	for {
+		chunk := make([]I, 0, chunkSize)
+		for len(chunk) < chunkSize {
+			item, ok := next()
+			if !ok {
+				break
+			}
+			chunk = append(chunk, item)
+		}
+		if len(chunk) == 0 {
+			break
+		}
+
+		partial := new(O)
+		partials = append(partials, partial)
+
+		workerSlots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-workerSlots }()
+			// A panic on a worker goroutine can't be recovered by the caller,
+			// so it is captured here and raised again on the caller's goroutine
+			defer func() {
+				if r := recover(); r != nil {
+					once.Do(func() {
+						workerPanic = &PanicError{Value: r, Stack: getErrWithStackTrace()}
+					})
+				}
+			}()
+			*partial = Reduce(chunk, reduceFunc, initialValue)
+		}()
+	}
+
+	wg.Wait()
+
+	if workerPanic != nil {
+		panic(workerPanic)
+	}
+
+	if len(partials) == 0 {
+		return initialValue
+	}
+
+	result := *partials[0]
+	for _, partial := range partials[1:] {
+		result = combine(result, *partial)
+	}
+
+	return result
+}
+
+// TakeSeq yields at most the first n elements of the sequence and then stops the upstream
+// sequence, so it can be used to bound infinite sequences.
+func TakeSeq[I any](inputSeq iter.Seq[I], n int) iter.Seq[I] {
+	return func(yield func(I) bool) {
+		if n <= 0 {
+			return
+		}
+
+		taken := 0
+		for input := range inputSeq {
+			if !yield(input) {
+				return
+			}
+			taken++
+			if taken == n {
+				return
+			}
+		}
+	}
+}
+
+// TakeWhileSeq yields the leading run of elements of the sequence that satisfy the predicate,
+// stopping the upstream sequence at the first element that doesn't.
+func TakeWhileSeq[I any](inputSeq iter.Seq[I], predicate func(I) bool) iter.Seq[I] {
+	return func(yield func(I) bool) {
+		for input := range inputSeq {
+			if !predicate(input) || !yield(input) {
+				return
+			}
+		}
+	}
+}
+
+// DropSeq skips the first n elements of the sequence and yields the rest.
+// Dropping more elements than the sequence has yields nothing.
+func DropSeq[I any](inputSeq iter.Seq[I], n int) iter.Seq[I] {
+	return func(yield func(I) bool) {
+		dropped := 0
+		for input := range inputSeq {
+			if dropped < n {
+				dropped++
+				continue
+			}
+			if !yield(input) {
+				return
+			}
+		}
+	}
+}
+
+// DropWhileSeq skips the leading run of elements of the sequence that satisfy the predicate
+// and then yields everything else, starting with the first element that failed it.
+func DropWhileSeq[I any](inputSeq iter.Seq[I], predicate func(I) bool) iter.Seq[I] {
+	return func(yield func(I) bool) {
+		dropping := true
+		for input := range inputSeq {
+			if dropping && predicate(input) {
+				continue
+			}
+			dropping = false
+			if !yield(input) {
+				return
+			}
+		}
+	}
+}
+
 // ExpandSeq takes an input sequence of type iter.Seq[I] and a reduce function
 // that transforms each element of type I into a slice of elements of type O.
 // It returns a new sequence of type iter.Seq[O] where each element of the input
@@ -238,6 +459,17 @@ func AnySeq[I any](inputSeq iter.Seq[I], anyFunc func(I) bool) bool {
 	return false
 }
 
+// CountSeq returns the number of elements in the sequence that satisfy the given predicate function.
+func CountSeq[I any](inputSeq iter.Seq[I], predicate func(I) bool) int {
+	count := 0
+	for input := range inputSeq {
+		if predicate(input) {
+			count++
+		}
+	}
+	return count
+}
+
 func DistinctSeq[I comparable](inputSeq iter.Seq[I]) iter.Seq[I] {
 	seen := make(map[I]bool)
 	return func(yield func(I) bool) {
@@ -252,7 +484,27 @@ func DistinctSeq[I comparable](inputSeq iter.Seq[I]) iter.Seq[I] {
 	}
 }
 
-func Ennumerate[I any](inputSeq iter.Seq[I]) iter.Seq2[int, I] {
+// DistinctBySeq lazily yields the first element seen for each key returned by keyFunc,
+// generalizing DistinctSeq to elements that are not comparable themselves.
+// The seen keys are kept in memory, so memory grows with the number of distinct keys.
+func DistinctBySeq[I any, K comparable](inputSeq iter.Seq[I], keyFunc func(I) K) iter.Seq[I] {
+	return func(yield func(I) bool) {
+		seen := make(map[K]struct{})
+		for input := range inputSeq {
+			key := keyFunc(input)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			if !yield(input) {
+				return
+			}
+		}
+	}
+}
+
+// Enumerate yields every element of the sequence along with its zero-based index.
+func Enumerate[I any](inputSeq iter.Seq[I]) iter.Seq2[int, I] {
 	return func(yield func(int, I) bool) {
 		index := 0
 		for input := range inputSeq {
@@ -264,14 +516,115 @@ func Ennumerate[I any](inputSeq iter.Seq[I]) iter.Seq2[int, I] {
 	}
 }
 
+// Ennumerate yields every element of the sequence along with its zero-based index.
+//
+// Deprecated: Use Enumerate instead, this spelling is kept for backwards compatibility.
+func Ennumerate[I any](inputSeq iter.Seq[I]) iter.Seq2[int, I] {
+	return Enumerate(inputSeq)
+}
+
+// ReverseSeq2 yields the pairs of the two-value sequence in reverse order, keeping
+// each key attached to its value, e.g. to walk the output of Enumerate backwards.
+// The whole input sequence is buffered before the first pair is yielded.
+func ReverseSeq2[K any, V any](in iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var keys []K
+		var values []V
+		for key, value := range in {
+			keys = append(keys, key)
+			values = append(values, value)
+		}
+
+		for i := len(keys) - 1; i >= 0; i-- {
+			if !yield(keys[i], values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// SumBySeq returns the sum of the values returned by fn for every element of the sequence,
+// starting from the zero value of N.
+func SumBySeq[I any, N Number](inputSeq iter.Seq[I], fn func(I) N) N {
+	var sum N
+	for input := range inputSeq {
+		sum += fn(input)
+	}
+	return sum
+}
+
+// CountBySeq returns how many elements of the sequence fall into each key returned by keyFunc.
+func CountBySeq[I any, K comparable](inputSeq iter.Seq[I], keyFunc func(I) K) map[K]int {
+	counts := make(map[K]int)
+	for input := range inputSeq {
+		counts[keyFunc(input)]++
+	}
+	return counts
+}
+
+// ZipSeq pairs the elements of a and b by position, pulling from both sequences in lockstep
+// until either of them is exhausted.
+func ZipSeq[A any, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		nextA, stopA := iter.Pull(a)
+		nextB, stopB := iter.Pull(b)
+		defer stopA()
+		defer stopB()
+
+		for {
+			currA, okA := nextA()
+			if !okA {
+				return
+			}
+			currB, okB := nextB()
+			if !okB {
+				return
+			}
+
+			if !yield(currA, currB) {
+				return
+			}
+		}
+	}
+}
+
+// SortSeq returns a sequence over the elements of inputSeq sorted in ascending order.
+// Sorting can't stream, so the whole input sequence is buffered and sorted before the first element is yielded.
+func SortSeq[I cmp.Ordered](inputSeq iter.Seq[I]) iter.Seq[I] {
+	return SortSeqFunc(inputSeq, func(a, b I) bool {
+		return a < b
+	})
+}
+
+// SortSeqFunc returns a sequence over the elements of inputSeq sorted with the provided less function.
+// Sorting can't stream, so the whole input sequence is buffered and sorted before the first element is yielded.
+func SortSeqFunc[I any](inputSeq iter.Seq[I], less func(a, b I) bool) iter.Seq[I] {
+	return func(yield func(I) bool) {
+		var buffer []I
+		for input := range inputSeq {
+			buffer = append(buffer, input)
+		}
+
+		for _, item := range Sort(buffer, less) {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// IntersectionSeq yields the elements present in both sequences, buffering inputSeq1 into a set first.
+// Each common element is yielded only once, in the order of its first appearance in inputSeq2.
 func IntersectionSeq[I comparable](inputSeq1, inputSeq2 iter.Seq[I]) iter.Seq[I] {
 	seen := make(map[I]bool)
 	return func(yield func(I) bool) {
 		for input := range inputSeq1 {
 			seen[input] = true
 		}
+		emitted := make(map[I]bool)
 		for input := range inputSeq2 {
-			if _, ok := seen[input]; ok {
+			if _, ok := seen[input]; ok && !emitted[input] {
+				emitted[input] = true
 				if !yield(input) {
 					return
 				}
@@ -318,6 +671,46 @@ func DifferenceSeq[I comparable](a, b iter.Seq[I]) iter.Seq[I] {
 	}
 }
 
+// SymmetricDifferenceSeq yields the elements that are present in exactly one of the two sequences,
+// each of them only once, following the order of first appearance in a and then in b.
+// b is fully buffered in memory before the first element is yielded, since the membership of
+// every element of a depends on it, while a is streamed and only its distinct elements are kept in a set.
+func SymmetricDifferenceSeq[I comparable](a, b iter.Seq[I]) iter.Seq[I] {
+	return func(yield func(I) bool) {
+		var bufferedB []I
+		inB := make(map[I]bool)
+		for input := range b {
+			if !inB[input] {
+				inB[input] = true
+				bufferedB = append(bufferedB, input)
+			}
+		}
+
+		inA := make(map[I]bool)
+		for input := range a {
+			if inA[input] {
+				continue
+			}
+			inA[input] = true
+			if inB[input] {
+				continue
+			}
+			if !yield(input) {
+				return
+			}
+		}
+
+		for _, input := range bufferedB {
+			if inA[input] {
+				continue
+			}
+			if !yield(input) {
+				return
+			}
+		}
+	}
+}
+
 func CompareSeq[I comparable](a, b iter.Seq[I]) bool {
 	nextA, stopA := iter.Pull(a)
 	nextB, stopB := iter.Pull(b)
@@ -342,6 +735,33 @@ func CompareSeq[I comparable](a, b iter.Seq[I]) bool {
 	}
 }
 
+// CompareFuncSeq reports whether both sequences yield the same number of elements
+// and every pair of corresponding elements satisfies eq.
+// Like CompareSeq, both sequences are pulled in lockstep, so it stops at the first mismatch.
+func CompareFuncSeq[I any](a, b iter.Seq[I], eq func(x, y I) bool) bool {
+	nextA, stopA := iter.Pull(a)
+	nextB, stopB := iter.Pull(b)
+	defer stopA()
+	defer stopB()
+
+	for {
+		currA, okA := nextA()
+		currB, okB := nextB()
+
+		if okA != okB {
+			return false
+		}
+
+		if !okA {
+			return true
+		}
+
+		if !eq(currA, currB) {
+			return false
+		}
+	}
+}
+
 func GroupBySeq[I any, K comparable](inputSeq iter.Seq[I], keyFunc func(I) K) iter.Seq2[K, iter.Seq[I]] {
 	groups := make(map[K][]I)
 
@@ -366,3 +786,192 @@ func GroupBySeq[I any, K comparable](inputSeq iter.Seq[I], keyFunc func(I) K) it
 		}
 	}
 }
+
+// GroupByReduceSeq consumes inputSeq, folding each element into the accumulator of the group
+// returned by keyFunc, and yields every (key, aggregate) pair once the source is exhausted.
+// Every group starts its fold from a copy of initialValue and keys are yielded in order of first appearance.
+// The copy is shallow, so a reference-typed initialValue (a slice with spare capacity, a map or a pointer)
+// is shared by all the groups; use a nil slice or a zero value for such accumulators.
+//
+// Only one accumulator per distinct key is buffered, never the elements of the groups.
+func GroupByReduceSeq[I any, K comparable, O any](inputSeq iter.Seq[I], keyFunc func(I) K, reduceFunc func(O, I) O, initialValue O) iter.Seq2[K, O] {
+	return func(yield func(K, O) bool) {
+		var keys []K
+		accumulators := make(map[K]O)
+
+		for input := range inputSeq {
+			key := keyFunc(input)
+			accumulator, ok := accumulators[key]
+			if !ok {
+				keys = append(keys, key)
+				accumulator = initialValue
+			}
+			accumulators[key] = reduceFunc(accumulator, input)
+		}
+
+		for _, key := range keys {
+			if !yield(key, accumulators[key]) {
+				return
+			}
+		}
+	}
+}
+
+// MergeSortedSeq lazily merges any number of sequences into a single sorted sequence,
+// keeping a small heap over the current head of every sequence (k-way merge).
+// Every input sequence must already be sorted according to less, otherwise the output is not sorted.
+// Elements that compare equal are yielded in the order of the sequences they come from.
+// All the pulled sequences are stopped when the merge ends or the consumer stops early.
+func MergeSortedSeq[I any](less func(a, b I) bool, seqs ...iter.Seq[I]) iter.Seq[I] {
+	type head struct {
+		value    I
+		seqIndex int
+	}
+
+	return func(yield func(I) bool) {
+		nexts := make([]func() (I, bool), len(seqs))
+		heads := &funcHeap[head]{
+			less: func(a, b head) bool {
+				if less(a.value, b.value) {
+					return true
+				}
+				if less(b.value, a.value) {
+					return false
+				}
+				return a.seqIndex < b.seqIndex
+			},
+		}
+
+		for i, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			defer stop()
+			nexts[i] = next
+
+			if value, ok := next(); ok {
+				heads.items = append(heads.items, head{value: value, seqIndex: i})
+			}
+		}
+		heap.Init(heads)
+
+		for heads.Len() > 0 {
+			smallest := heads.items[0]
+			if !yield(smallest.value) {
+				return
+			}
+
+			if value, ok := nexts[smallest.seqIndex](); ok {
+				heads.items[0] = head{value: value, seqIndex: smallest.seqIndex}
+				heap.Fix(heads, 0)
+			} else {
+				heap.Pop(heads)
+			}
+		}
+	}
+}
+
+// WindowSeq yields the overlapping windows of the given size as the sequence advances,
+// keeping only a ring buffer of size elements in memory. No window is yielded until at least
+// size elements have been seen, and nothing is yielded if size is less than or equal to 0.
+// Every yielded window is a fresh slice, so it can be retained by the consumer.
+func WindowSeq[I any](inputSeq iter.Seq[I], size int) iter.Seq[[]I] {
+	return func(yield func([]I) bool) {
+		if size <= 0 {
+			return
+		}
+
+		ring := make([]I, size)
+		seen := 0
+		for input := range inputSeq {
+			ring[seen%size] = input
+			seen++
+			if seen < size {
+				continue
+			}
+
+			// The oldest element of the window sits right after the newest one
+			start := seen % size
+			window := make([]I, 0, size)
+			window = append(window, ring[start:]...)
+			window = append(window, ring[:start]...)
+			if !yield(window) {
+				return
+			}
+		}
+	}
+}
+
+// ChunkWhenSeq groups the elements of the sequence into chunks, emitting the accumulated
+// buffer whenever shouldFlush returns true for the incoming element, which then starts a fresh buffer.
+// shouldFlush is only called when the buffer is not empty. The final non-empty buffer is always
+// flushed at the end of the sequence. Every yielded chunk has its own backing array.
+func ChunkWhenSeq[I any](inputSeq iter.Seq[I], shouldFlush func(current []I, next I) bool) iter.Seq[[]I] {
+	return func(yield func([]I) bool) {
+		var buffer []I
+		for input := range inputSeq {
+			if len(buffer) > 0 && shouldFlush(buffer, input) {
+				if !yield(buffer) {
+					return
+				}
+				buffer = nil
+			}
+			buffer = append(buffer, input)
+		}
+
+		if len(buffer) > 0 {
+			yield(buffer)
+		}
+	}
+}
+
+// TopKSeq returns the k greatest elements of the sequence according to less,
+// keeping a bounded min-heap of k elements so that it only needs O(k) memory
+// regardless of the length of the sequence.
+// The returned slice is sorted from the greatest to the smallest element and
+// holds fewer than k elements if the sequence is shorter than k.
+func TopKSeq[I any](inputSeq iter.Seq[I], k int, less func(a, b I) bool) []I {
+	if k <= 0 {
+		return []I{}
+	}
+
+	topK := &funcHeap[I]{less: less}
+	for input := range inputSeq {
+		if topK.Len() < k {
+			heap.Push(topK, input)
+			continue
+		}
+		if less(topK.items[0], input) {
+			topK.items[0] = input
+			heap.Fix(topK, 0)
+		}
+	}
+
+	return Sort(topK.items, func(a, b I) bool {
+		return less(b, a)
+	})
+}
+
+// Collect drains the sequence into a freshly allocated slice.
+// It returns a non-nil empty slice for an empty sequence.
+func Collect[I any](inputSeq iter.Seq[I]) []I {
+	return AppendSeq(make([]I, 0), inputSeq)
+}
+
+// AppendSeq appends every element of the sequence to dst and returns the grown slice,
+// letting the caller control the allocation, e.g. to accumulate several sequences into one buffer.
+func AppendSeq[I any, S ~[]I](dst S, inputSeq iter.Seq[I]) S {
+	for input := range inputSeq {
+		dst = append(dst, input)
+	}
+	return dst
+}
+
+// SeqToMap drains the sequence into a map built from the key/value pairs returned by fn.
+// If several elements produce the same key, later elements overwrite earlier ones.
+func SeqToMap[I any, K comparable, V any](inputSeq iter.Seq[I], fn func(I) (K, V)) map[K]V {
+	result := make(map[K]V)
+	for input := range inputSeq {
+		key, value := fn(input)
+		result[key] = value
+	}
+	return result
+}
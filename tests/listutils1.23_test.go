@@ -4,6 +4,8 @@
 package tests
 
 import (
+	"errors"
+	"iter"
 	"slices"
 	"testing"
 
@@ -192,3 +194,556 @@ func TestGroupBySeq(t *testing.T) {
 		}
 	}
 }
+
+func TestGroupByReduceSeq(t *testing.T) {
+	expectedKeys := []string{"odd", "even"}
+	expected := map[string]int{"even": 30, "odd": 25}
+
+	result := slicesutils.GroupByReduceSeq(itemsSeq, func(item int) string {
+		if item%2 == 0 {
+			return "even"
+		}
+		return "odd"
+	}, func(acc int, item int) int {
+		return acc + item
+	}, 0)
+
+	index := 0
+	for key, sum := range result {
+		if key != expectedKeys[index] {
+			t.Errorf("Expected key %s, but got %s", expectedKeys[index], key)
+		}
+		if sum != expected[key] {
+			t.Errorf("Expected %v for key %s, but got %v", expected[key], key, sum)
+		}
+		index++
+	}
+
+	if index != len(expectedKeys) {
+		t.Errorf("Expected %d groups, but got %d", len(expectedKeys), index)
+	}
+}
+
+func TestGroupByReduceSeq_SliceAccumulator(t *testing.T) {
+	expected := map[bool][]int{true: {1, 3}, false: {2, 4}}
+
+	result := slicesutils.GroupByReduceSeq(slices.Values([]int{1, 2, 3, 4}), func(item int) bool {
+		return item%2 != 0
+	}, func(acc []int, item int) []int {
+		return append(acc, item)
+	}, make([]int, 0))
+
+	groups := 0
+	for key, value := range result {
+		if !slicesutils.Compare(expected[key], value) {
+			t.Errorf("Expected %v for key %v, but got %v", expected[key], key, value)
+		}
+		groups++
+	}
+
+	if groups != len(expected) {
+		t.Errorf("Expected %d groups, but got %d", len(expected), groups)
+	}
+}
+
+func TestCompareFuncSeq(t *testing.T) {
+	a := slices.Values([]IdentifiableItem{{ID: 1, Type: "A"}, {ID: 2, Type: "B"}})
+	b := slices.Values([]IdentifiableItem{{ID: 1, Type: "X"}, {ID: 2, Type: "Y"}})
+	shorter := slices.Values([]IdentifiableItem{{ID: 1, Type: "A"}})
+
+	sameId := func(x, y IdentifiableItem) bool {
+		return x.ID == y.ID
+	}
+
+	if !slicesutils.CompareFuncSeq(a, b, sameId) {
+		t.Errorf("Expected sequences to be equal by id")
+	}
+
+	if slicesutils.CompareFuncSeq(a, shorter, sameId) {
+		t.Errorf("Expected sequences of different length to differ")
+	}
+
+	if slicesutils.CompareFuncSeq(shorter, a, sameId) {
+		t.Errorf("Expected sequences of different length to differ")
+	}
+}
+
+func TestSafeMapSeqErr(t *testing.T) {
+	errTooBig := errors.New("too big")
+
+	result, errFunc := slicesutils.SafeMapSeqErr(itemsSeq, func(item int) (int, error) {
+		if item > 3 {
+			return 0, errTooBig
+		}
+		return item * 2, nil
+	})
+
+	expected := slices.Values([]int{2, 4, 6})
+	if ok := slicesutils.CompareSeq(expected, result); !ok {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	if err := errFunc(); !errors.Is(err, errTooBig) {
+		t.Errorf("Expected error %v, but got %v", errTooBig, err)
+	}
+
+	result, errFunc = slicesutils.SafeMapSeqErr(itemsSeq, func(item int) (int, error) {
+		return item, nil
+	})
+
+	for range result {
+	}
+
+	if err := errFunc(); err != nil {
+		t.Errorf("Expected no error, but got %v", err)
+	}
+}
+
+func TestMergeSortedSeq(t *testing.T) {
+	a := slices.Values([]int{1, 4, 7, 10})
+	b := slices.Values([]int{2, 5, 8})
+	c := slices.Values([]int{3, 6, 9})
+	expected := slices.Values(items)
+
+	result := slicesutils.MergeSortedSeq(func(x, y int) bool {
+		return x < y
+	}, a, b, c, slices.Values([]int{}))
+
+	if ok := slicesutils.CompareSeq(expected, result); !ok {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+}
+
+func TestChunkWhenSeq(t *testing.T) {
+	expected := [][]int{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}, {10}}
+
+	result := slicesutils.ChunkWhenSeq(itemsSeq, func(current []int, next int) bool {
+		return len(current) == 3
+	})
+
+	index := 0
+	for chunk := range result {
+		if index >= len(expected) {
+			t.Fatalf("Unexpected extra chunk %v", chunk)
+		}
+		if ok := slicesutils.Compare(expected[index], chunk); !ok {
+			t.Errorf("Expected %v, but got %v", expected[index], chunk)
+		}
+		index++
+	}
+
+	if index != len(expected) {
+		t.Errorf("Expected %d chunks, but got %d", len(expected), index)
+	}
+}
+
+func TestReverseSeq2(t *testing.T) {
+	input := slices.Values([]string{"a", "b", "c"})
+	expectedIndexes := []int{2, 1, 0}
+	expectedValues := []string{"c", "b", "a"}
+
+	position := 0
+	for index, value := range slicesutils.ReverseSeq2(slicesutils.Enumerate(input)) {
+		if index != expectedIndexes[position] || value != expectedValues[position] {
+			t.Errorf("Expected (%d, %s), but got (%d, %s)", expectedIndexes[position], expectedValues[position], index, value)
+		}
+		position++
+	}
+
+	if position != len(expectedValues) {
+		t.Errorf("Expected %d pairs, but got %d", len(expectedValues), position)
+	}
+}
+
+func TestSumBySeq(t *testing.T) {
+	input := slices.Values([]IdentifiableItem{{ID: 1}, {ID: 2}, {ID: 3}})
+
+	result := slicesutils.SumBySeq(input, func(item IdentifiableItem) float64 {
+		return float64(item.ID) / 2
+	})
+
+	if result != 3 {
+		t.Errorf("Expected 3, but got %v", result)
+	}
+}
+
+func TestCountBySeq(t *testing.T) {
+	result := slicesutils.CountBySeq(itemsSeq, func(item int) bool {
+		return item > 7
+	})
+
+	if result[true] != 3 || result[false] != 7 {
+		t.Errorf("Expected map[false:7 true:3], but got %v", result)
+	}
+}
+
+func TestTopKSeq(t *testing.T) {
+	input := slices.Values([]int{5, 1, 9, 3, 7, 10, 2, 8, 4, 6})
+	expected := []int{10, 9, 8}
+
+	result := slicesutils.TopKSeq(input, 3, func(a, b int) bool {
+		return a < b
+	})
+
+	if ok := slicesutils.Compare(expected, result); !ok {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	result = slicesutils.TopKSeq(slices.Values([]int{2, 1}), 5, func(a, b int) bool {
+		return a < b
+	})
+
+	if ok := slicesutils.Compare([]int{2, 1}, result); !ok {
+		t.Errorf("Expected %v, but got %v", []int{2, 1}, result)
+	}
+}
+
+func TestParallelReduceSeq(t *testing.T) {
+	sum := func(acc, item int) int {
+		return acc + item
+	}
+
+	result := slicesutils.ParallelReduceSeq(itemsSeq, 3, sum, sum, 0)
+	if result != 55 {
+		t.Errorf("Expected 55, but got %d", result)
+	}
+
+	concat := slicesutils.ParallelReduceSeq(itemsSeq, 4, func(acc string, item int) string {
+		return acc + string(rune('a'+item-1))
+	}, func(a, b string) string {
+		return a + b
+	}, "")
+	if concat != "abcdefghij" {
+		t.Errorf("Expected abcdefghij, but got %s", concat)
+	}
+
+	result = slicesutils.ParallelReduceSeq(slices.Values([]int{}), 3, sum, sum, 0)
+	if result != 0 {
+		t.Errorf("Expected 0, but got %d", result)
+	}
+}
+
+func TestParallelReduceSeq_SliceAccumulator(t *testing.T) {
+	expected := []int{1, 2, 3, 4, 5, 6, 7, 8}
+
+	result := slicesutils.ParallelReduceSeq(slices.Values(expected), 2, func(acc []int, item int) []int {
+		return append(acc, item)
+	}, func(a, b []int) []int {
+		return append(a, b...)
+	}, nil)
+
+	if ok := slicesutils.Compare(expected, result); !ok {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+}
+
+func TestParallelReduceSeq_Panic(t *testing.T) {
+	errPanic := errors.New("boom")
+
+	defer func() {
+		r := recover()
+		panicErr, ok := r.(*slicesutils.PanicError)
+		if !ok || !errors.Is(panicErr, errPanic) {
+			t.Errorf("Expected a recoverable PanicError wrapping %v, but got %v", errPanic, r)
+		}
+	}()
+
+	slicesutils.ParallelReduceSeq(itemsSeq, 3, func(acc, item int) int {
+		if item == 5 {
+			panic(errPanic)
+		}
+		return acc + item
+	}, func(a, b int) int {
+		return a + b
+	}, 0)
+
+	t.Errorf("Expected ParallelReduceSeq to panic on the caller's goroutine")
+}
+
+func TestCountSeq(t *testing.T) {
+	result := slicesutils.CountSeq(itemsSeq, func(item int) bool {
+		return item > 8
+	})
+
+	if result != 2 {
+		t.Errorf("Expected 2, but got %d", result)
+	}
+}
+
+func TestScanSeq(t *testing.T) {
+	expected := slices.Values([]int{1, 3, 6, 10, 15, 21, 28, 36, 45, 55})
+
+	result := slicesutils.ScanSeq(itemsSeq, func(acc, item int) int {
+		return acc + item
+	}, 0)
+
+	if ok := slicesutils.CompareSeq(expected, result); !ok {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+}
+
+func TestMinSeq(t *testing.T) {
+	input := slices.Values([]float64{3.5, -1.5, 9, 0})
+
+	if result := slicesutils.MinSeq(input); result != -1.5 {
+		t.Errorf("Expected -1.5, but got %v", result)
+	}
+
+	if result := slicesutils.MinSeq(itemsSeq); result != 1 {
+		t.Errorf("Expected 1, but got %d", result)
+	}
+}
+
+func TestMinSeq_Empty(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "MinSeq: empty sequence" {
+			t.Errorf("Expected panic \"MinSeq: empty sequence\", but got %v", r)
+		}
+	}()
+
+	slicesutils.MinSeq(slices.Values([]int{}))
+}
+
+func TestMinSeqFunc(t *testing.T) {
+	input := slices.Values([]IdentifiableItem{{ID: 3}, {ID: 1}, {ID: 2}})
+
+	result := slicesutils.MinSeqFunc(input, func(a, b IdentifiableItem) IdentifiableItem {
+		if b.ID < a.ID {
+			return b
+		}
+		return a
+	})
+
+	if result.ID != 1 {
+		t.Errorf("Expected item 1, but got %v", result)
+	}
+}
+
+// naturals yields 1, 2, 3... forever, counting how many numbers were generated.
+func naturals(generated *int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := 1; ; i++ {
+			*generated = i
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func TestTakeSeq(t *testing.T) {
+	generated := 0
+
+	result := slicesutils.TakeSeq(naturals(&generated), 3)
+
+	if ok := slicesutils.CompareSeq(slices.Values([]int{1, 2, 3}), result); !ok {
+		t.Errorf("Expected %v, but got %v", []int{1, 2, 3}, result)
+	}
+
+	if generated != 3 {
+		t.Errorf("Expected the generator to stop after 3 elements, but it generated %d", generated)
+	}
+
+	generated = 0
+	for range slicesutils.TakeSeq(naturals(&generated), 0) {
+		t.Errorf("Expected no elements")
+	}
+
+	if generated != 0 {
+		t.Errorf("Expected the generator not to run, but it generated %d", generated)
+	}
+}
+
+func TestTakeWhileSeq(t *testing.T) {
+	generated := 0
+
+	result := slicesutils.TakeWhileSeq(naturals(&generated), func(item int) bool {
+		return item < 5
+	})
+
+	if ok := slicesutils.CompareSeq(slices.Values([]int{1, 2, 3, 4}), result); !ok {
+		t.Errorf("Expected %v, but got %v", []int{1, 2, 3, 4}, result)
+	}
+
+	if generated != 5 {
+		t.Errorf("Expected the generator to stop after 5 elements, but it generated %d", generated)
+	}
+}
+
+func TestDropSeq(t *testing.T) {
+	if ok := slicesutils.CompareSeq(slices.Values([]int{8, 9, 10}), slicesutils.DropSeq(itemsSeq, 7)); !ok {
+		t.Errorf("Expected %v", []int{8, 9, 10})
+	}
+
+	if ok := slicesutils.CompareSeq(itemsSeq, slicesutils.DropSeq(itemsSeq, 0)); !ok {
+		t.Errorf("Expected %v", items)
+	}
+
+	for item := range slicesutils.DropSeq(itemsSeq, 20) {
+		t.Errorf("Expected no elements, but got %d", item)
+	}
+}
+
+func TestDropWhileSeq(t *testing.T) {
+	input := slices.Values([]int{1, 2, 5, 1, 2})
+	expected := slices.Values([]int{5, 1, 2})
+
+	result := slicesutils.DropWhileSeq(input, func(item int) bool {
+		return item < 3
+	})
+
+	if ok := slicesutils.CompareSeq(expected, result); !ok {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+}
+
+func TestZipSeq(t *testing.T) {
+	expectedNumbers := []int{1, 2, 3}
+	expectedLetters := []string{"a", "b", "c"}
+
+	index := 0
+	for number, letter := range slicesutils.ZipSeq(itemsSeq, slices.Values(expectedLetters)) {
+		if number != expectedNumbers[index] || letter != expectedLetters[index] {
+			t.Errorf("Expected (%d, %s), but got (%d, %s)", expectedNumbers[index], expectedLetters[index], number, letter)
+		}
+		index++
+	}
+
+	if index != len(expectedLetters) {
+		t.Errorf("Expected %d pairs, but got %d", len(expectedLetters), index)
+	}
+}
+
+func TestSortSeq(t *testing.T) {
+	input := slices.Values([]int{5, 3, 9, 1, 7})
+	expected := slices.Values([]int{1, 3, 5, 7, 9})
+
+	if ok := slicesutils.CompareSeq(expected, slicesutils.SortSeq(input)); !ok {
+		t.Errorf("Expected %v", []int{1, 3, 5, 7, 9})
+	}
+}
+
+func TestSortSeqFunc(t *testing.T) {
+	input := slices.Values([]int{5, 3, 9, 1, 7})
+	expected := slices.Values([]int{9, 7, 5, 3, 1})
+
+	result := slicesutils.SortSeqFunc(input, func(a, b int) bool {
+		return a > b
+	})
+
+	if ok := slicesutils.CompareSeq(expected, result); !ok {
+		t.Errorf("Expected %v", []int{9, 7, 5, 3, 1})
+	}
+}
+
+func TestWindowSeq(t *testing.T) {
+	input := slices.Values([]int{1, 2, 3, 4, 5})
+	expected := [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}
+
+	var result [][]int
+	for window := range slicesutils.WindowSeq(input, 3) {
+		result = append(result, window)
+	}
+
+	if len(result) != len(expected) {
+		t.Fatalf("Expected %v, but got %v", expected, result)
+	}
+
+	for i, window := range result {
+		if ok := slicesutils.Compare(expected[i], window); !ok {
+			t.Errorf("Expected %v, but got %v", expected[i], window)
+		}
+	}
+
+	for window := range slicesutils.WindowSeq(input, 6) {
+		t.Errorf("Expected no windows, but got %v", window)
+	}
+}
+
+func TestEnumerate(t *testing.T) {
+	input := slices.Values([]string{"a", "b", "c"})
+
+	position := 0
+	for index, value := range slicesutils.Enumerate(input) {
+		if index != position || value != string(rune('a'+position)) {
+			t.Errorf("Expected (%d, %c), but got (%d, %s)", position, 'a'+position, index, value)
+		}
+		position++
+	}
+
+	if position != 3 {
+		t.Errorf("Expected 3 pairs, but got %d", position)
+	}
+}
+
+func TestCollect(t *testing.T) {
+	if result := slicesutils.Collect(itemsSeq); !slicesutils.Compare(items, result) {
+		t.Errorf("Expected %v, but got %v", items, result)
+	}
+
+	if result := slicesutils.Collect(slices.Values([]int{})); result == nil || len(result) != 0 {
+		t.Errorf("Expected a non-nil empty slice, but got %v", result)
+	}
+}
+
+func TestSeqToMap(t *testing.T) {
+	result := slicesutils.SeqToMap(itemsSeq, func(item int) (int, int) {
+		return item % 3, item
+	})
+
+	if len(result) != 3 || result[0] != 9 || result[1] != 10 || result[2] != 8 {
+		t.Errorf("Expected map[0:9 1:10 2:8], but got %v", result)
+	}
+}
+
+func TestAppendSeq(t *testing.T) {
+	buffer := make([]int, 0, 6)
+
+	buffer = slicesutils.AppendSeq(buffer, slices.Values([]int{1, 2, 3}))
+	buffer = slicesutils.AppendSeq(buffer, slices.Values([]int{4, 5}))
+
+	if ok := slicesutils.Compare([]int{1, 2, 3, 4, 5}, buffer); !ok {
+		t.Errorf("Expected %v, but got %v", []int{1, 2, 3, 4, 5}, buffer)
+	}
+
+	if cap(buffer) != 6 {
+		t.Errorf("Expected the buffer to be reused, but its capacity is %d", cap(buffer))
+	}
+}
+
+func TestDistinctBySeq(t *testing.T) {
+	input := slices.Values([]IdentifiableItem{{ID: 1, Type: "A"}, {ID: 2, Type: "B"}, {ID: 1, Type: "C"}, {ID: 3, Type: "D"}})
+	expected := slices.Values([]IdentifiableItem{{ID: 1, Type: "A"}, {ID: 2, Type: "B"}, {ID: 3, Type: "D"}})
+
+	result := slicesutils.DistinctBySeq(input, func(item IdentifiableItem) int {
+		return item.ID
+	})
+
+	if ok := slicesutils.CompareSeq(expected, result); !ok {
+		t.Errorf("Expected %v", slicesutils.Collect(expected))
+	}
+}
+
+func TestIntersectionSeq(t *testing.T) {
+	a := slices.Values([]int{1, 2, 3, 4})
+	b := slices.Values([]int{4, 2, 4, 5, 2, 4})
+	expected := slices.Values([]int{4, 2})
+
+	result := slicesutils.IntersectionSeq(a, b)
+
+	if ok := slicesutils.CompareSeq(expected, result); !ok {
+		t.Errorf("Expected %v, but got %v", slicesutils.Collect(expected), slicesutils.Collect(result))
+	}
+}
+
+func TestSymmetricDifferenceSeq(t *testing.T) {
+	a := slices.Values([]int{1, 2, 2, 3, 4})
+	b := slices.Values([]int{3, 5, 4, 6, 5})
+	expected := slices.Values([]int{1, 2, 5, 6})
+
+	result := slicesutils.SymmetricDifferenceSeq(a, b)
+
+	if ok := slicesutils.CompareSeq(expected, result); !ok {
+		t.Errorf("Expected %v, but got %v", slicesutils.Collect(expected), slicesutils.Collect(result))
+	}
+}
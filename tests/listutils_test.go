@@ -1,7 +1,15 @@
 package tests
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/AngelTheTwin/slicesutils"
 )
@@ -332,3 +340,1823 @@ func TestWeightedSort(t *testing.T) {
 		t.Errorf("Expected %v, but got %v", expected, result)
 	}
 }
+
+func TestGroupByReduce(t *testing.T) {
+	input := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	expected := map[string]int{"even": 30, "odd": 25}
+
+	result := slicesutils.GroupByReduce(input, func(item int) string {
+		if item%2 == 0 {
+			return "even"
+		}
+		return "odd"
+	}, func(acc int, item int) int {
+		return acc + item
+	}, 0)
+
+	if len(result) != len(expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	for key, value := range expected {
+		if result[key] != value {
+			t.Errorf("Expected %v for key %s, but got %v", value, key, result[key])
+		}
+	}
+}
+
+func TestGroupByReduce_SliceAccumulator(t *testing.T) {
+	expected := map[bool][]int{true: {1, 3}, false: {2, 4}}
+
+	result := slicesutils.GroupByReduce([]int{1, 2, 3, 4}, func(item int) bool {
+		return item%2 != 0
+	}, func(acc []int, item int) []int {
+		return append(acc, item)
+	}, nil)
+
+	if len(result) != len(expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	for key, value := range expected {
+		if !slicesutils.Compare(value, result[key]) {
+			t.Errorf("Expected %v for key %v, but got %v", value, key, result[key])
+		}
+	}
+}
+
+func TestParallelMapInto(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	expected := []int{2, 4, 6, 8, 10, 12, 14, 16, 18, 20}
+	buffer := make([]int, 0, len(items))
+
+	result := slicesutils.ParallelMapInto(buffer, items, func(item int) int {
+		return item * 2
+	})
+
+	if ok := slicesutils.Compare(expected, result); !ok {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	if &result[0] != &buffer[:1][0] {
+		t.Errorf("Expected the destination buffer to be reused")
+	}
+
+	result = slicesutils.ParallelMapInto(result, items[:3], func(item int) int {
+		return item * 3
+	})
+
+	if ok := slicesutils.Compare([]int{3, 6, 9}, result); !ok {
+		t.Errorf("Expected %v, but got %v", []int{3, 6, 9}, result)
+	}
+}
+
+func TestOptimalChunkSize(t *testing.T) {
+	cases := []struct {
+		sliceLen, workers, expected int
+	}{
+		{10, 3, 4},
+		{9, 3, 3},
+		{2, 4, 1},
+		{0, 4, 1},
+	}
+
+	for _, c := range cases {
+		if result := slicesutils.OptimalChunkSize(c.sliceLen, c.workers); result != c.expected {
+			t.Errorf("Expected %d for (%d, %d), but got %d", c.expected, c.sliceLen, c.workers, result)
+		}
+	}
+
+	if result := slicesutils.OptimalChunkSize(10, 0); result < 1 {
+		t.Errorf("Expected a positive chunk size, but got %d", result)
+	}
+}
+
+func TestExtract(t *testing.T) {
+	input := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	original := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	expectedKept := []int{1, 3, 5, 7, 9}
+	expectedRemoved := []int{2, 4, 6, 8}
+
+	kept, removed := slicesutils.Extract(input, func(item int) bool {
+		return item%2 == 0
+	})
+
+	if ok := slicesutils.Compare(expectedKept, kept); !ok {
+		t.Errorf("Expected %v, but got %v", expectedKept, kept)
+	}
+
+	if ok := slicesutils.Compare(expectedRemoved, removed); !ok {
+		t.Errorf("Expected %v, but got %v", expectedRemoved, removed)
+	}
+
+	if ok := slicesutils.Compare(original, input); !ok {
+		t.Errorf("Expected input to remain %v, but got %v", original, input)
+	}
+}
+
+func TestGroupByStringer(t *testing.T) {
+	input := []IdentifiableItem{
+		{ID: 1, Type: "A"},
+		{ID: 2, Type: "B"},
+		{ID: 1, Type: "A"},
+		{ID: 3, Type: "A"},
+	}
+
+	result := slicesutils.GroupByStringer(input, func(item IdentifiableItem) string {
+		return fmt.Sprintf("%d-%s", item.ID, item.Type)
+	})
+
+	if len(result) != 3 {
+		t.Errorf("Expected 3 groups, but got %d", len(result))
+	}
+
+	if group := result["1-A"]; len(group) != 2 {
+		t.Errorf("Expected 2 items in group 1-A, but got %v", group)
+	}
+}
+
+func TestWeightedSample(t *testing.T) {
+	input := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	r := rand.New(rand.NewSource(42))
+
+	result := slicesutils.WeightedSample(input, 3, func(item int) float64 {
+		if item > 5 {
+			return 0
+		}
+		return float64(item)
+	}, r)
+
+	if len(result) != 3 {
+		t.Errorf("Expected 3 elements, but got %v", result)
+	}
+
+	if len(slicesutils.Distinct(result)) != len(result) {
+		t.Errorf("Expected distinct elements, but got %v", result)
+	}
+
+	for _, item := range result {
+		if item > 5 {
+			t.Errorf("Expected zero-weight element %d to never be picked", item)
+		}
+	}
+
+	result = slicesutils.WeightedSample(input, 20, func(item int) float64 {
+		return 1
+	}, r)
+
+	if len(result) != len(input) {
+		t.Errorf("Expected %d elements, but got %v", len(input), result)
+	}
+}
+
+func TestWeightedSample_Proportional(t *testing.T) {
+	input := []string{"light", "heavy"}
+	r := rand.New(rand.NewSource(42))
+	weights := map[string]float64{"light": 1, "heavy": 9}
+
+	picks := map[string]int{}
+	for i := 0; i < 10000; i++ {
+		result := slicesutils.WeightedSample(input, 1, func(item string) float64 {
+			return weights[item]
+		}, r)
+		picks[result[0]]++
+	}
+
+	// The heavy element is expected to be picked around 9 times as often as the light one
+	if picks["heavy"] < 8*picks["light"] || picks["heavy"] > 10*picks["light"] {
+		t.Errorf("Expected picks proportional to the weights, but got %v", picks)
+	}
+}
+
+func TestWeightedSample_InvalidWeight(t *testing.T) {
+	for _, weight := range []float64{-1, math.NaN()} {
+		func() {
+			defer func() {
+				if r := recover(); r != "WeightedSample: negative or NaN weight" {
+					t.Errorf("Expected panic \"WeightedSample: negative or NaN weight\" for %v, but got %v", weight, r)
+				}
+			}()
+
+			slicesutils.WeightedSample(items, 3, func(item int) float64 {
+				return weight
+			}, rand.New(rand.NewSource(42)))
+		}()
+	}
+}
+
+func TestCommonPrefix(t *testing.T) {
+	a := []string{"usr", "local", "bin", "go"}
+	b := []string{"usr", "local", "lib"}
+	expected := []string{"usr", "local"}
+
+	if length := slicesutils.CommonPrefixLength(a, b); length != 2 {
+		t.Errorf("Expected 2, but got %d", length)
+	}
+
+	if result := slicesutils.CommonPrefix(a, b); !slicesutils.Compare(expected, result) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	if result := slicesutils.CommonPrefix(a, nil); len(result) != 0 {
+		t.Errorf("Expected empty prefix, but got %v", result)
+	}
+}
+
+func TestCommonSuffixLength(t *testing.T) {
+	a := []int{1, 2, 3, 4}
+	b := []int{9, 3, 4}
+
+	if length := slicesutils.CommonSuffixLength(a, b); length != 2 {
+		t.Errorf("Expected 2, but got %d", length)
+	}
+
+	if length := slicesutils.CommonSuffixLength(a, []int{}); length != 0 {
+		t.Errorf("Expected 0, but got %d", length)
+	}
+}
+
+func TestClamp(t *testing.T) {
+	input := []float64{-5, 0.5, 3, 12, 7}
+	expected := []float64{0, 0.5, 3, 10, 7}
+
+	result := slicesutils.Clamp(input, 0, 10)
+
+	if ok := slicesutils.Compare(expected, result); !ok {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+}
+
+func TestClamp_InvalidRange(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "Clamp: low is greater than high" {
+			t.Errorf("Expected panic \"Clamp: low is greater than high\", but got %v", r)
+		}
+	}()
+
+	slicesutils.Clamp([]int{1, 2, 3}, 5, 1)
+}
+
+func TestDrainChan(t *testing.T) {
+	ch := make(chan int, 10)
+	for _, item := range items {
+		ch <- item
+	}
+
+	result := slicesutils.DrainChan(ch, 4)
+	if ok := slicesutils.Compare([]int{1, 2, 3, 4}, result); !ok {
+		t.Errorf("Expected %v, but got %v", []int{1, 2, 3, 4}, result)
+	}
+
+	result = slicesutils.DrainChan(ch, 10)
+	if ok := slicesutils.Compare([]int{5, 6, 7, 8, 9, 10}, result); !ok {
+		t.Errorf("Expected %v, but got %v", []int{5, 6, 7, 8, 9, 10}, result)
+	}
+
+	close(ch)
+	result = slicesutils.DrainChan(ch, 10)
+	if len(result) != 0 {
+		t.Errorf("Expected empty slice, but got %v", result)
+	}
+}
+
+func TestTransformMapValues(t *testing.T) {
+	groups := map[string][]int{"even": {2, 4, 6}, "odd": {1, 3}}
+
+	result := slicesutils.TransformMapValues(groups, func(group []int) int {
+		return len(group)
+	})
+
+	if result["even"] != 3 || result["odd"] != 2 || len(result) != 2 {
+		t.Errorf("Expected map[even:3 odd:2], but got %v", result)
+	}
+}
+
+func TestTransformMapKeys(t *testing.T) {
+	input := map[int]string{1: "one", 2: "two"}
+
+	result := slicesutils.TransformMapKeys(input, func(key int) string {
+		return fmt.Sprintf("key-%d", key)
+	})
+
+	if result["key-1"] != "one" || result["key-2"] != "two" || len(result) != 2 {
+		t.Errorf("Expected map[key-1:one key-2:two], but got %v", result)
+	}
+}
+
+func TestFilterType(t *testing.T) {
+	input := []any{1, "two", 3, IdentifiableItem{ID: 4}, 5.0}
+	expected := []int{1, 3}
+
+	result := slicesutils.FilterType[int](input)
+
+	if ok := slicesutils.Compare(expected, result); !ok {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	errs := []error{fmt.Errorf("wrapped: %w", errors.New("plain")), errors.New("plain")}
+	if result := slicesutils.FilterType[interface{ Unwrap() error }](errs); len(result) != 1 {
+		t.Errorf("Expected 1 wrapping error, but got %v", result)
+	}
+}
+
+func TestParallelMapTimed(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	expected := []int{2, 4, 6, 8, 10, 12, 14, 16, 18, 20}
+
+	result, stats := slicesutils.ParallelMapTimed(items, func(item int) int {
+		time.Sleep(time.Millisecond)
+		return item * 2
+	})
+
+	if ok := slicesutils.Compare(expected, result); !ok {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	processed := slicesutils.Reduce(stats.ItemsPerWorker, func(acc, count int) int {
+		return acc + count
+	}, 0)
+	if processed != len(items) {
+		t.Errorf("Expected %d processed items, but got %d", len(items), processed)
+	}
+
+	if stats.MinItemDuration < time.Millisecond || stats.MinItemDuration > stats.MaxItemDuration {
+		t.Errorf("Unexpected item durations: min %v, max %v", stats.MinItemDuration, stats.MaxItemDuration)
+	}
+
+	if stats.AvgItemDuration < stats.MinItemDuration || stats.AvgItemDuration > stats.MaxItemDuration {
+		t.Errorf("Expected average %v between min and max", stats.AvgItemDuration)
+	}
+
+	if stats.TotalDuration < stats.MaxItemDuration {
+		t.Errorf("Expected total duration %v to be at least %v", stats.TotalDuration, stats.MaxItemDuration)
+	}
+}
+
+func TestParallelMapTimed_Panic(t *testing.T) {
+	errPanic := errors.New("boom")
+
+	defer func() {
+		r := recover()
+		panicErr, ok := r.(*slicesutils.PanicError)
+		if !ok || !errors.Is(panicErr, errPanic) {
+			t.Errorf("Expected a recoverable PanicError wrapping %v, but got %v", errPanic, r)
+		}
+	}()
+
+	slicesutils.ParallelMapTimed(items, func(item int) int {
+		if item == 4 {
+			panic(errPanic)
+		}
+		return item
+	})
+
+	t.Errorf("Expected ParallelMapTimed to panic on the caller's goroutine")
+}
+
+func TestEqualUnorderedBy(t *testing.T) {
+	a := []IdentifiableItem{{ID: 1, Type: "A"}, {ID: 2, Type: "B"}, {ID: 2, Type: "B"}}
+	b := []IdentifiableItem{{ID: 2, Type: "B"}, {ID: 1, Type: "A"}, {ID: 2, Type: "B"}}
+	c := []IdentifiableItem{{ID: 2, Type: "B"}, {ID: 1, Type: "A"}, {ID: 1, Type: "A"}}
+
+	byId := func(item IdentifiableItem) int {
+		return item.ID
+	}
+
+	if !slicesutils.EqualUnorderedBy(a, b, byId) {
+		t.Errorf("Expected %v and %v to be equal", a, b)
+	}
+
+	if slicesutils.EqualUnorderedBy(a, c, byId) {
+		t.Errorf("Expected %v and %v to differ", a, c)
+	}
+}
+
+func TestGroupBySorted(t *testing.T) {
+	input := []IdentifiableItem{
+		{ID: 5, Type: "A"},
+		{ID: 2, Type: "B"},
+		{ID: 3, Type: "A"},
+		{ID: 1, Type: "B"},
+		{ID: 4, Type: "A"},
+	}
+	expectedA := []IdentifiableItem{{ID: 3, Type: "A"}, {ID: 4, Type: "A"}, {ID: 5, Type: "A"}}
+	expectedB := []IdentifiableItem{{ID: 1, Type: "B"}, {ID: 2, Type: "B"}}
+
+	result := slicesutils.GroupBySorted(input, func(item IdentifiableItem) string {
+		return item.Type
+	}, func(a, b IdentifiableItem) bool {
+		return a.ID < b.ID
+	})
+
+	if ok := slicesutils.Compare(expectedA, result["A"]); !ok {
+		t.Errorf("Expected %v, but got %v", expectedA, result["A"])
+	}
+
+	if ok := slicesutils.Compare(expectedB, result["B"]); !ok {
+		t.Errorf("Expected %v, but got %v", expectedB, result["B"])
+	}
+}
+
+func TestForEachWhile(t *testing.T) {
+	visited := []int{}
+
+	slicesutils.ForEachWhile(items, func(item int) bool {
+		visited = append(visited, item)
+		return item < 4
+	})
+
+	if ok := slicesutils.Compare([]int{1, 2, 3, 4}, visited); !ok {
+		t.Errorf("Expected %v, but got %v", []int{1, 2, 3, 4}, visited)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	input := []float64{10, 20, 15, 30}
+	expected := []float64{0, 0.5, 0.25, 1}
+
+	result := slicesutils.Normalize(input)
+
+	if ok := slicesutils.Compare(expected, result); !ok {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	result = slicesutils.Normalize([]float32{3, 3, 3})
+
+	if ok := slicesutils.Compare([]float64{0, 0, 0}, result); !ok {
+		t.Errorf("Expected %v, but got %v", []float64{0, 0, 0}, result)
+	}
+
+	result = slicesutils.Normalize([]float32{-3e38, 0, 3e38})
+
+	if ok := slicesutils.Compare([]float64{0, 0.5, 1}, result); !ok {
+		t.Errorf("Expected %v, but got %v", []float64{0, 0.5, 1}, result)
+	}
+
+	result = slicesutils.Normalize([]float64{-1e308, 0, 1e308})
+
+	if ok := slicesutils.Compare([]float64{0, 0.5, 1}, result); !ok {
+		t.Errorf("Expected %v, but got %v", []float64{0, 0.5, 1}, result)
+	}
+}
+
+func TestCircularWindow(t *testing.T) {
+	input := []int{1, 2, 3}
+	expected := [][]int{{1, 2}, {2, 3}, {3, 1}}
+
+	result := slicesutils.CircularWindow(input, 2)
+
+	if len(result) != len(expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	for i, window := range result {
+		if ok := slicesutils.Compare(expected[i], window); !ok {
+			t.Errorf("Expected %v, but got %v", expected[i], window)
+		}
+	}
+
+	if result := slicesutils.CircularWindow(input, 4); len(result) != 0 {
+		t.Errorf("Expected empty result, but got %v", result)
+	}
+}
+
+func TestSafeExcecuteCtx(t *testing.T) {
+	result, err := slicesutils.SafeExcecuteCtx(context.Background(), func(ctx context.Context) (int, error) {
+		return 42, nil
+	})
+
+	if err != nil || result != 42 {
+		t.Errorf("Expected (42, nil), but got (%v, %v)", result, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = slicesutils.SafeExcecuteCtx(ctx, func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		time.Sleep(50 * time.Millisecond)
+		return 0, nil
+	})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected %v, but got %v", context.DeadlineExceeded, err)
+	}
+
+	errPanic := errors.New("boom")
+	_, err = slicesutils.SafeExcecuteCtx(context.Background(), func(ctx context.Context) (int, error) {
+		panic(errPanic)
+	})
+
+	if !errors.Is(err, errPanic) {
+		t.Errorf("Expected %v, but got %v", errPanic, err)
+	}
+}
+
+func TestFlattenDeep(t *testing.T) {
+	input := []any{1, []any{2, "skip", []any{3, []int{4, 5}}}, 6.5, []any{[]any{[]any{7}}}}
+
+	result := slicesutils.FlattenDeep[int](input, -1)
+	if ok := slicesutils.Compare([]int{1, 2, 3, 4, 5, 7}, result); !ok {
+		t.Errorf("Expected %v, but got %v", []int{1, 2, 3, 4, 5, 7}, result)
+	}
+
+	result = slicesutils.FlattenDeep[int](input, 2)
+	if ok := slicesutils.Compare([]int{1, 2}, result); !ok {
+		t.Errorf("Expected %v, but got %v", []int{1, 2}, result)
+	}
+
+	anyResult := slicesutils.FlattenDeep[any]([]any{1, []any{2, 3}}, -1)
+	if ok := slicesutils.CompareFunc([]any{1, 2, 3}, anyResult, func(x, y any) bool { return x == y }); !ok {
+		t.Errorf("Expected %v, but got %v", []any{1, 2, 3}, anyResult)
+	}
+
+	sliceResult := slicesutils.FlattenDeep[[]int]([]any{[]int{1, 2}, []any{[]int{3}}}, 1)
+	if len(sliceResult) != 1 || !slicesutils.Compare([]int{1, 2}, sliceResult[0]) {
+		t.Errorf("Expected %v, but got %v", [][]int{{1, 2}}, sliceResult)
+	}
+}
+
+func TestMergeJoin(t *testing.T) {
+	customers := []IdentifiableItem{{ID: 1, Type: "Ann"}, {ID: 2, Type: "Bob"}, {ID: 4, Type: "Dan"}}
+	orders := []int{1, 1, 3, 4}
+	expected := []string{"Ann-1", "Ann-1", "Dan-4"}
+
+	result := []string{}
+	slicesutils.MergeJoin(customers, orders, func(customer IdentifiableItem) int {
+		return customer.ID
+	}, func(order int) int {
+		return order
+	}, func(customer IdentifiableItem, order int) {
+		result = append(result, fmt.Sprintf("%s-%d", customer.Type, order))
+	})
+
+	if ok := slicesutils.Compare(expected, result); !ok {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+}
+
+func TestMin(t *testing.T) {
+	if result := slicesutils.Min(7); result != 7 {
+		t.Errorf("Expected 7, but got %d", result)
+	}
+
+	if result := slicesutils.Min(3, -4, 9, -1); result != -4 {
+		t.Errorf("Expected -4, but got %d", result)
+	}
+
+	if result := slicesutils.Min(2.5, 1.5, 3.5); result != 1.5 {
+		t.Errorf("Expected 1.5, but got %v", result)
+	}
+
+	if result := slicesutils.Min("pear", "apple", "zucchini"); result != "apple" {
+		t.Errorf("Expected apple, but got %s", result)
+	}
+
+	if result := slicesutils.Min(Heavy, Light); result != Heavy {
+		t.Errorf("Expected %s, but got %s", Heavy, result)
+	}
+}
+
+func TestMin_Empty(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "No element provided to Min" {
+			t.Errorf("Expected panic \"No element provided to Min\", but got %v", r)
+		}
+	}()
+
+	slicesutils.Min[int]()
+}
+
+func TestMax(t *testing.T) {
+	if result := slicesutils.Max(3, 1, 9, 5); result != 9 {
+		t.Errorf("Expected 9, but got %d", result)
+	}
+
+	if result := slicesutils.Max(-2.5, -1.5, -3.5); result != -1.5 {
+		t.Errorf("Expected -1.5, but got %v", result)
+	}
+
+	if result := slicesutils.Max("pear", "apple", "zucchini"); result != "zucchini" {
+		t.Errorf("Expected zucchini, but got %s", result)
+	}
+
+	if result := slicesutils.Max(Heavy, Light); result != Light {
+		t.Errorf("Expected %s, but got %s", Light, result)
+	}
+}
+
+func TestMax_Empty(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "No element provided to Max" {
+			t.Errorf("Expected panic \"No element provided to Max\", but got %v", r)
+		}
+	}()
+
+	slicesutils.Max[float64]()
+}
+
+func TestMinByMaxBy(t *testing.T) {
+	input := []IdentifiableItem{
+		{ID: 3, Type: "B"},
+		{ID: 1, Type: "A"},
+		{ID: 1, Type: "C"},
+		{ID: 5, Type: "D"},
+		{ID: 5, Type: "E"},
+	}
+	byId := func(item IdentifiableItem) int {
+		return item.ID
+	}
+
+	minItem, ok := slicesutils.MinBy(input, byId)
+	if !ok || minItem.Type != "A" {
+		t.Errorf("Expected item A, but got %v", minItem)
+	}
+
+	maxItem, ok := slicesutils.MaxBy(input, byId)
+	if !ok || maxItem.Type != "D" {
+		t.Errorf("Expected item D, but got %v", maxItem)
+	}
+
+	if _, ok := slicesutils.MinBy([]IdentifiableItem{}, byId); ok {
+		t.Errorf("Expected not to find a minimum in an empty slice")
+	}
+
+	if _, ok := slicesutils.MaxBy([]IdentifiableItem{}, byId); ok {
+		t.Errorf("Expected not to find a maximum in an empty slice")
+	}
+}
+
+func TestSum(t *testing.T) {
+	if result := slicesutils.Sum(items); result != 55 {
+		t.Errorf("Expected 55, but got %d", result)
+	}
+
+	if result := slicesutils.Sum([]float64{0.5, 1.5, -1}); result != 1 {
+		t.Errorf("Expected 1, but got %v", result)
+	}
+
+	if result := slicesutils.Sum([]uint8{}); result != 0 {
+		t.Errorf("Expected 0, but got %d", result)
+	}
+}
+
+func TestSumBy(t *testing.T) {
+	input := []IdentifiableItem{{ID: 1}, {ID: 2}, {ID: 3}}
+
+	result := slicesutils.SumBy(input, func(item IdentifiableItem) int {
+		return item.ID * 10
+	})
+
+	if result != 60 {
+		t.Errorf("Expected 60, but got %d", result)
+	}
+}
+
+func TestZip(t *testing.T) {
+	expected := []slicesutils.Pair[int, string]{{First: 1, Second: "a"}, {First: 2, Second: "b"}}
+
+	result := slicesutils.Zip([]int{1, 2, 3}, []string{"a", "b"})
+
+	if ok := slicesutils.Compare(expected, result); !ok {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+}
+
+func TestUnzip(t *testing.T) {
+	firsts, seconds := slicesutils.Unzip(slicesutils.Zip([]int{1, 2, 3}, []string{"a", "b", "c"}))
+
+	if ok := slicesutils.Compare([]int{1, 2, 3}, firsts); !ok {
+		t.Errorf("Expected %v, but got %v", []int{1, 2, 3}, firsts)
+	}
+
+	if ok := slicesutils.Compare([]string{"a", "b", "c"}, seconds); !ok {
+		t.Errorf("Expected %v, but got %v", []string{"a", "b", "c"}, seconds)
+	}
+
+	firsts, seconds = slicesutils.Unzip[int, string](nil)
+	if firsts == nil || seconds == nil || len(firsts) != 0 || len(seconds) != 0 {
+		t.Errorf("Expected two empty slices, but got %v and %v", firsts, seconds)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	input := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	original := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+
+	matched, unmatched := slicesutils.Partition(input, func(item int) bool {
+		return item%3 == 0
+	})
+
+	if ok := slicesutils.Compare([]int{3, 6, 9}, matched); !ok {
+		t.Errorf("Expected %v, but got %v", []int{3, 6, 9}, matched)
+	}
+
+	if ok := slicesutils.Compare([]int{1, 2, 4, 5, 7, 8}, unmatched); !ok {
+		t.Errorf("Expected %v, but got %v", []int{1, 2, 4, 5, 7, 8}, unmatched)
+	}
+
+	if len(matched)+len(unmatched) != len(input) {
+		t.Errorf("Expected the results to partition %v exactly", input)
+	}
+
+	if ok := slicesutils.Compare(original, input); !ok {
+		t.Errorf("Expected input to remain %v, but got %v", original, input)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	result := slicesutils.GroupBy(items, func(item int) bool {
+		return item%2 == 0
+	})
+
+	if ok := slicesutils.Compare([]int{2, 4, 6, 8, 10}, result[true]); !ok {
+		t.Errorf("Expected %v, but got %v", []int{2, 4, 6, 8, 10}, result[true])
+	}
+
+	if ok := slicesutils.Compare([]int{1, 3, 5, 7, 9}, result[false]); !ok {
+		t.Errorf("Expected %v, but got %v", []int{1, 3, 5, 7, 9}, result[false])
+	}
+}
+
+func TestCountBy(t *testing.T) {
+	result := slicesutils.CountBy(items, func(item int) string {
+		if item <= 3 {
+			return "small"
+		}
+		return "big"
+	})
+
+	if result["small"] != 3 || result["big"] != 7 || len(result) != 2 {
+		t.Errorf("Expected map[big:7 small:3], but got %v", result)
+	}
+
+	result = slicesutils.CountBy([]int{}, func(item int) string {
+		return ""
+	})
+
+	if result == nil || len(result) != 0 {
+		t.Errorf("Expected empty map, but got %v", result)
+	}
+}
+
+func TestCount(t *testing.T) {
+	result := slicesutils.Count(items, func(item int) bool {
+		return item%3 == 0
+	})
+
+	if result != 3 {
+		t.Errorf("Expected 3, but got %d", result)
+	}
+}
+
+func TestIndexOf(t *testing.T) {
+	input := []string{"a", "b", "c", "b"}
+
+	if index := slicesutils.IndexOf(input, "b"); index != 1 {
+		t.Errorf("Expected index 1, but got %d", index)
+	}
+
+	if index := slicesutils.IndexOf(input, "z"); index != -1 {
+		t.Errorf("Expected index -1, but got %d", index)
+	}
+}
+
+func TestLastIndexOf(t *testing.T) {
+	input := []string{"a", "b", "c", "b"}
+
+	if index := slicesutils.LastIndexOf(input, "b"); index != 3 {
+		t.Errorf("Expected index 3, but got %d", index)
+	}
+
+	if index := slicesutils.LastIndexOf(input, "z"); index != -1 {
+		t.Errorf("Expected index -1, but got %d", index)
+	}
+}
+
+func TestFindLastIndex(t *testing.T) {
+	index := slicesutils.FindLastIndex(items, func(item int) bool {
+		return item%4 == 0
+	})
+
+	if index != 7 {
+		t.Errorf("Expected index 7, but got %d", index)
+	}
+
+	index = slicesutils.FindLastIndex(items, func(item int) bool {
+		return item > 10
+	})
+
+	if index != -1 {
+		t.Errorf("Expected index -1, but got %d", index)
+	}
+}
+
+func TestFindLast(t *testing.T) {
+	item, ok := slicesutils.FindLast(items, func(item int) bool {
+		return item%3 == 0
+	})
+
+	if !ok || item != 9 {
+		t.Errorf("Expected to find item 9, but got (%d, %v)", item, ok)
+	}
+
+	item, ok = slicesutils.FindLast(items, func(item int) bool {
+		return item == 11
+	})
+
+	if ok || item != 0 {
+		t.Errorf("Expected (0, false), but got (%d, %v)", item, ok)
+	}
+}
+
+func TestTake(t *testing.T) {
+	if result := slicesutils.Take(items, 3); !slicesutils.Compare([]int{1, 2, 3}, result) {
+		t.Errorf("Expected %v, but got %v", []int{1, 2, 3}, result)
+	}
+
+	if result := slicesutils.Take(items, 20); !slicesutils.Compare(items, result) {
+		t.Errorf("Expected %v, but got %v", items, result)
+	}
+
+	if result := slicesutils.Take(items, -1); len(result) != 0 {
+		t.Errorf("Expected empty slice, but got %v", result)
+	}
+}
+
+func TestTakeWhile(t *testing.T) {
+	result := slicesutils.TakeWhile(items, func(item int) bool {
+		return item < 4
+	})
+
+	if ok := slicesutils.Compare([]int{1, 2, 3}, result); !ok {
+		t.Errorf("Expected %v, but got %v", []int{1, 2, 3}, result)
+	}
+
+	result = slicesutils.TakeWhile(items, func(item int) bool {
+		return true
+	})
+
+	if ok := slicesutils.Compare(items, result); !ok {
+		t.Errorf("Expected %v, but got %v", items, result)
+	}
+}
+
+func TestDrop(t *testing.T) {
+	if result := slicesutils.Drop(items, 7); !slicesutils.Compare([]int{8, 9, 10}, result) {
+		t.Errorf("Expected %v, but got %v", []int{8, 9, 10}, result)
+	}
+
+	if result := slicesutils.Drop(items, 20); len(result) != 0 {
+		t.Errorf("Expected empty slice, but got %v", result)
+	}
+
+	if result := slicesutils.Drop(items, 0); !slicesutils.Compare(items, result) {
+		t.Errorf("Expected %v, but got %v", items, result)
+	}
+}
+
+func TestDropWhile(t *testing.T) {
+	result := slicesutils.DropWhile(items, func(item int) bool {
+		return item < 8
+	})
+
+	if ok := slicesutils.Compare([]int{8, 9, 10}, result); !ok {
+		t.Errorf("Expected %v, but got %v", []int{8, 9, 10}, result)
+	}
+
+	result = slicesutils.DropWhile(items, func(item int) bool {
+		return true
+	})
+
+	if len(result) != 0 {
+		t.Errorf("Expected empty slice, but got %v", result)
+	}
+}
+
+func TestWindow(t *testing.T) {
+	input := []int{1, 2, 3, 4}
+	expected := [][]int{{1, 2}, {2, 3}, {3, 4}}
+
+	result := slicesutils.Window(input, 2)
+
+	if len(result) != len(expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	for i, window := range result {
+		if ok := slicesutils.Compare(expected[i], window); !ok {
+			t.Errorf("Expected %v, but got %v", expected[i], window)
+		}
+	}
+
+	if result := slicesutils.Window(input, 5); len(result) != 0 {
+		t.Errorf("Expected empty result, but got %v", result)
+	}
+
+	if result := slicesutils.Window(input, 0); len(result) != 0 {
+		t.Errorf("Expected empty result, but got %v", result)
+	}
+}
+
+func TestFill(t *testing.T) {
+	input := make([]string, 3)
+
+	result := slicesutils.Fill(input, "x")
+
+	if ok := slicesutils.Compare([]string{"x", "x", "x"}, result); !ok {
+		t.Errorf("Expected %v, but got %v", []string{"x", "x", "x"}, result)
+	}
+}
+
+func TestRepeat(t *testing.T) {
+	if result := slicesutils.Repeat(7, 4); !slicesutils.Compare([]int{7, 7, 7, 7}, result) {
+		t.Errorf("Expected %v, but got %v", []int{7, 7, 7, 7}, result)
+	}
+
+	if result := slicesutils.Repeat(7, -2); len(result) != 0 {
+		t.Errorf("Expected empty slice, but got %v", result)
+	}
+}
+
+func TestRange(t *testing.T) {
+	if result := slicesutils.Range(0, 10, 3); !slicesutils.Compare([]int{0, 3, 6, 9}, result) {
+		t.Errorf("Expected %v, but got %v", []int{0, 3, 6, 9}, result)
+	}
+
+	if result := slicesutils.Range(5, 0, -2); !slicesutils.Compare([]int{5, 3, 1}, result) {
+		t.Errorf("Expected %v, but got %v", []int{5, 3, 1}, result)
+	}
+
+	if result := slicesutils.Range(5, 0, 1); len(result) != 0 {
+		t.Errorf("Expected empty slice, but got %v", result)
+	}
+
+	if result := slicesutils.Range(math.MaxInt-1, math.MaxInt, 2); !slicesutils.Compare([]int{math.MaxInt - 1}, result) {
+		t.Errorf("Expected %v, but got %v", []int{math.MaxInt - 1}, result)
+	}
+
+	if result := slicesutils.Range(math.MinInt+1, math.MinInt, -2); !slicesutils.Compare([]int{math.MinInt + 1}, result) {
+		t.Errorf("Expected %v, but got %v", []int{math.MinInt + 1}, result)
+	}
+
+	if result := slicesutils.Range(math.MaxInt-5, math.MaxInt, 2); !slicesutils.Compare([]int{math.MaxInt - 5, math.MaxInt - 3, math.MaxInt - 1}, result) {
+		t.Errorf("Expected %v, but got %v", []int{math.MaxInt - 5, math.MaxInt - 3, math.MaxInt - 1}, result)
+	}
+}
+
+func TestRange_ZeroStep(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "Range: step cannot be zero" {
+			t.Errorf("Expected panic \"Range: step cannot be zero\", but got %v", r)
+		}
+	}()
+
+	slicesutils.Range(0, 10, 0)
+}
+
+func TestInsertAt(t *testing.T) {
+	cases := []struct {
+		input    []int
+		index    int
+		elements []int
+		expected []int
+	}{
+		{[]int{1, 2, 3}, 0, []int{8, 9}, []int{8, 9, 1, 2, 3}},
+		{[]int{1, 2, 3}, 1, []int{8}, []int{1, 8, 2, 3}},
+		{[]int{1, 2, 3}, 3, []int{8, 9}, []int{1, 2, 3, 8, 9}},
+		{[]int{}, 0, []int{8}, []int{8}},
+		{[]int{1, 2, 3}, 2, []int{}, []int{1, 2, 3}},
+	}
+
+	for _, c := range cases {
+		result, err := slicesutils.InsertAt(c.input, c.index, c.elements...)
+		if err != nil {
+			t.Errorf("Expected no error, but got %v", err)
+		}
+		if ok := slicesutils.Compare(c.expected, result); !ok {
+			t.Errorf("Expected %v, but got %v", c.expected, result)
+		}
+	}
+}
+
+func TestInsertAt_OutOfRange(t *testing.T) {
+	input := []int{1, 2, 3}
+
+	if _, err := slicesutils.InsertAt(input, 4, 8); !errors.Is(err, slicesutils.ErrIndexOutOfRange) {
+		t.Errorf("Expected %v, but got %v", slicesutils.ErrIndexOutOfRange, err)
+	}
+
+	if _, err := slicesutils.InsertAt(input, -1, 8); !errors.Is(err, slicesutils.ErrIndexOutOfRange) {
+		t.Errorf("Expected %v, but got %v", slicesutils.ErrIndexOutOfRange, err)
+	}
+}
+
+func TestRemoveAt(t *testing.T) {
+	input := []int{1, 2, 3, 4}
+
+	result, err := slicesutils.RemoveAt(input, 1)
+	if err != nil {
+		t.Errorf("Expected no error, but got %v", err)
+	}
+	if ok := slicesutils.Compare([]int{1, 3, 4}, result); !ok {
+		t.Errorf("Expected %v, but got %v", []int{1, 3, 4}, result)
+	}
+
+	result, err = slicesutils.RemoveAt(result, 2)
+	if err != nil {
+		t.Errorf("Expected no error, but got %v", err)
+	}
+	if ok := slicesutils.Compare([]int{1, 3}, result); !ok {
+		t.Errorf("Expected %v, but got %v", []int{1, 3}, result)
+	}
+
+	if _, err := slicesutils.RemoveAt(result, 2); !errors.Is(err, slicesutils.ErrIndexOutOfRange) {
+		t.Errorf("Expected %v, but got %v", slicesutils.ErrIndexOutOfRange, err)
+	}
+}
+
+func TestRemoveRange(t *testing.T) {
+	input := []int{1, 2, 3, 4, 5, 6}
+
+	result, err := slicesutils.RemoveRange(input, 1, 4)
+	if err != nil {
+		t.Errorf("Expected no error, but got %v", err)
+	}
+	if ok := slicesutils.Compare([]int{1, 5, 6}, result); !ok {
+		t.Errorf("Expected %v, but got %v", []int{1, 5, 6}, result)
+	}
+
+	if _, err := slicesutils.RemoveRange(result, 2, 1); !errors.Is(err, slicesutils.ErrIndexOutOfRange) {
+		t.Errorf("Expected %v, but got %v", slicesutils.ErrIndexOutOfRange, err)
+	}
+}
+
+func TestRotate(t *testing.T) {
+	cases := []struct {
+		n        int
+		expected []int
+	}{
+		{2, []int{3, 4, 5, 1, 2}},
+		{-1, []int{5, 1, 2, 3, 4}},
+		{12, []int{3, 4, 5, 1, 2}},
+		{5, []int{1, 2, 3, 4, 5}},
+		{0, []int{1, 2, 3, 4, 5}},
+	}
+
+	for _, c := range cases {
+		result := slicesutils.Rotate([]int{1, 2, 3, 4, 5}, c.n)
+		if ok := slicesutils.Compare(c.expected, result); !ok {
+			t.Errorf("Expected %v for n=%d, but got %v", c.expected, c.n, result)
+		}
+	}
+
+	if result := slicesutils.Rotate([]int{}, 3); len(result) != 0 {
+		t.Errorf("Expected empty slice, but got %v", result)
+	}
+}
+
+func TestSample(t *testing.T) {
+	input := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	original := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	r := rand.New(rand.NewSource(7))
+
+	result := slicesutils.Sample(input, 4, r)
+
+	if len(result) != 4 || len(slicesutils.CountBy(result, func(item int) int { return item })) != 4 {
+		t.Errorf("Expected 4 distinct elements, but got %v", result)
+	}
+
+	for _, item := range result {
+		if !slicesutils.Contains(input, item) {
+			t.Errorf("Unexpected element %d in %v", item, result)
+		}
+	}
+
+	result = slicesutils.Sample(input, 20, r)
+
+	if ok := slicesutils.EqualUnorderedBy(input, result, func(item int) int { return item }); !ok {
+		t.Errorf("Expected a permutation of %v, but got %v", input, result)
+	}
+
+	if ok := slicesutils.Compare(original, input); !ok {
+		t.Errorf("Expected input to remain %v, but got %v", original, input)
+	}
+}
+
+func TestKeyBy(t *testing.T) {
+	input := []IdentifiableItem{{ID: 1, Type: "A"}, {ID: 2, Type: "B"}, {ID: 1, Type: "C"}}
+
+	result := slicesutils.KeyBy(input, func(item IdentifiableItem) int {
+		return item.ID
+	})
+
+	if len(result) != 2 || result[1].Type != "C" || result[2].Type != "B" {
+		t.Errorf("Expected map[1:{1 C} 2:{2 B}], but got %v", result)
+	}
+}
+
+func TestAssociate(t *testing.T) {
+	input := []IdentifiableItem{{ID: 1, Type: "A"}, {ID: 2, Type: "B"}, {ID: 1, Type: "C"}}
+
+	result := slicesutils.Associate(input, func(item IdentifiableItem) (int, string) {
+		return item.ID, item.Type
+	})
+
+	if len(result) != 2 || result[1] != "C" || result[2] != "B" {
+		t.Errorf("Expected map[1:C 2:B], but got %v", result)
+	}
+}
+
+func TestMapToSlice(t *testing.T) {
+	input := map[int]string{1: "a", 2: "b", 3: "c"}
+	expected := []string{"1a", "2b", "3c"}
+
+	result := slicesutils.MapToSlice(input, func(key int, value string) string {
+		return fmt.Sprintf("%d%s", key, value)
+	})
+
+	if ok := slicesutils.Compare(expected, slicesutils.Sort(result, func(a, b string) bool { return a < b })); !ok {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+}
+
+func TestReverse(t *testing.T) {
+	cases := []struct {
+		input    []int
+		expected []int
+	}{
+		{[]int{}, []int{}},
+		{[]int{1}, []int{1}},
+		{[]int{1, 2}, []int{2, 1}},
+		{[]int{1, 2, 3}, []int{3, 2, 1}},
+		{[]int{1, 2, 3, 4}, []int{4, 3, 2, 1}},
+		{[]int{1, 2, 3, 4, 5}, []int{5, 4, 3, 2, 1}},
+	}
+
+	for _, c := range cases {
+		result := slicesutils.Reverse(c.input)
+		if ok := slicesutils.Compare(c.expected, result); !ok {
+			t.Errorf("Expected %v, but got %v", c.expected, result)
+		}
+	}
+}
+
+func TestReverseCopy(t *testing.T) {
+	input := []int{1, 2, 3, 4}
+
+	result := slicesutils.ReverseCopy(input)
+
+	if ok := slicesutils.Compare([]int{4, 3, 2, 1}, result); !ok {
+		t.Errorf("Expected %v, but got %v", []int{4, 3, 2, 1}, result)
+	}
+
+	if ok := slicesutils.Compare([]int{1, 2, 3, 4}, input); !ok {
+		t.Errorf("Expected input to remain %v, but got %v", []int{1, 2, 3, 4}, input)
+	}
+}
+
+func TestFilterCopy(t *testing.T) {
+	input := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	original := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	expected := []int{2, 4, 6, 8}
+
+	result := slicesutils.FilterCopy(input, func(item int) bool {
+		return item%2 == 0
+	})
+
+	if ok := slicesutils.Compare(expected, result); !ok {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	if ok := slicesutils.Compare(original, input); !ok {
+		t.Errorf("Expected input to remain %v, but got %v", original, input)
+	}
+}
+
+func TestMapInPlace(t *testing.T) {
+	input := []int{1, 2, 3}
+
+	result := slicesutils.MapInPlace(input, func(item int) int {
+		return item * 10
+	})
+
+	if ok := slicesutils.Compare([]int{10, 20, 30}, result); !ok {
+		t.Errorf("Expected %v, but got %v", []int{10, 20, 30}, result)
+	}
+
+	if &result[0] != &input[0] {
+		t.Errorf("Expected the input slice to be reused")
+	}
+}
+
+func TestParallelMapErr(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	expected := []int{2, 4, 6, 8, 10, 12, 14, 16, 18, 20}
+
+	result, err := slicesutils.ParallelMapErr(items, func(item int) (int, error) {
+		return item * 2, nil
+	})
+
+	if err != nil {
+		t.Errorf("Expected no error, but got %v", err)
+	}
+
+	if ok := slicesutils.Compare(expected, result); !ok {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	_, err = slicesutils.ParallelMapErr(items, func(item int) (int, error) {
+		if item%3 == 0 {
+			return 0, fmt.Errorf("item %d", item)
+		}
+		return item, nil
+	})
+
+	if err == nil || err.Error() != "item 3" {
+		t.Errorf("Expected error for item 3, but got %v", err)
+	}
+}
+
+func TestParallelMapErr_Panic(t *testing.T) {
+	errPanic := errors.New("boom")
+
+	_, err := slicesutils.ParallelMapErr(items, func(item int) (int, error) {
+		if item == 5 {
+			panic(errPanic)
+		}
+		return item, nil
+	})
+
+	if !errors.Is(err, errPanic) {
+		t.Errorf("Expected %v, but got %v", errPanic, err)
+	}
+}
+
+func TestParallelMapWithWorkers(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	expected := []int{2, 4, 6, 8, 10, 12, 14, 16, 18, 20}
+
+	for _, workers := range []int{-1, 0, 1, 3, 50} {
+		result := slicesutils.ParallelMapWithWorkers(items, workers, func(item int) int {
+			return item * 2
+		})
+
+		if ok := slicesutils.Compare(expected, result); !ok {
+			t.Errorf("Expected %v with %d workers, but got %v", expected, workers, result)
+		}
+	}
+}
+
+func TestParallelForEachContext(t *testing.T) {
+	var mu sync.Mutex
+	sum := 0
+
+	err := slicesutils.ParallelForEachContext(context.Background(), items, func(ctx context.Context, item int) error {
+		mu.Lock()
+		defer mu.Unlock()
+		sum += item
+		return nil
+	})
+
+	if err != nil || sum != 55 {
+		t.Errorf("Expected (55, nil), but got (%d, %v)", sum, err)
+	}
+
+	errStop := errors.New("stop")
+	err = slicesutils.ParallelForEachContext(context.Background(), slicesutils.Range(0, 10000, 1), func(ctx context.Context, item int) error {
+		if item == 3 {
+			return errStop
+		}
+		return nil
+	})
+
+	if !errors.Is(err, errStop) {
+		t.Errorf("Expected %v, but got %v", errStop, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = slicesutils.ParallelForEachContext(ctx, items, func(ctx context.Context, item int) error {
+		return nil
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected %v, but got %v", context.Canceled, err)
+	}
+}
+
+func TestParallelForEachErr(t *testing.T) {
+	err := slicesutils.ParallelForEachErr(items, func(item int) error {
+		return nil
+	})
+
+	if err != nil {
+		t.Errorf("Expected no error, but got %v", err)
+	}
+
+	errPanic := errors.New("boom")
+	err = slicesutils.ParallelForEachErr(items, func(item int) error {
+		if item == 7 {
+			panic(errPanic)
+		}
+		return nil
+	})
+
+	if !errors.Is(err, errPanic) {
+		t.Errorf("Expected %v, but got %v", errPanic, err)
+	}
+}
+
+type panicPayload struct {
+	Code int
+}
+
+func TestSafeExcecute_NonErrorPanic(t *testing.T) {
+	cases := []struct {
+		value    any
+		expected string
+	}{
+		{"some string", "panic: some string"},
+		{42, "panic: 42"},
+		{panicPayload{Code: 7}, "panic: {7}"},
+	}
+
+	for _, c := range cases {
+		_, err := slicesutils.SafeExcecute(func() (int, error) {
+			panic(c.value)
+		})
+
+		if err == nil || err.Error() != c.expected {
+			t.Errorf("Expected error %q, but got %v", c.expected, err)
+		}
+
+		_, err = slicesutils.SafeExcecuteWithStackTrace(func() (int, error) {
+			panic(c.value)
+		})
+
+		if err == nil || !strings.HasPrefix(err.Error(), c.expected+"\nStack trace:\n") {
+			t.Errorf("Expected error starting with %q, but got %v", c.expected, err)
+		}
+	}
+}
+
+type sentinelError struct {
+	Reason string
+}
+
+func (e *sentinelError) Error() string {
+	return e.Reason
+}
+
+func TestSafeExcecute_PanicErrorUnwrap(t *testing.T) {
+	original := &sentinelError{Reason: "bad input"}
+
+	_, err := slicesutils.SafeMap(items, func(item int) (int, error) {
+		panic(original)
+	})
+
+	var panicErr *slicesutils.PanicError
+	if !errors.As(err, &panicErr) || panicErr.Value != original {
+		t.Errorf("Expected a PanicError wrapping %v, but got %v", original, err)
+	}
+
+	var target *sentinelError
+	if !errors.As(err, &target) || target != original {
+		t.Errorf("Expected errors.As to find %v, but got %v", original, err)
+	}
+
+	if !errors.Is(err, original) {
+		t.Errorf("Expected errors.Is to match %v", original)
+	}
+
+	_, err = slicesutils.SafeExcecute(func() (int, error) {
+		panic("not an error")
+	})
+
+	if !errors.As(err, &panicErr) || errors.Unwrap(err) != nil {
+		t.Errorf("Expected a PanicError with nothing to unwrap, but got %v", err)
+	}
+}
+
+func TestParallelMap_Panic(t *testing.T) {
+	errPanic := errors.New("boom")
+
+	defer func() {
+		r := recover()
+		panicErr, ok := r.(*slicesutils.PanicError)
+		if !ok || !errors.Is(panicErr, errPanic) {
+			t.Errorf("Expected a recoverable PanicError wrapping %v, but got %v", errPanic, r)
+		}
+	}()
+
+	slicesutils.ParallelMap(items, func(item int) int {
+		if item == 4 {
+			panic(errPanic)
+		}
+		return item
+	})
+
+	t.Errorf("Expected ParallelMap to panic on the caller's goroutine")
+}
+
+func TestParallelMap_PanicWithSafeExcecute(t *testing.T) {
+	_, err := slicesutils.SafeExcecute(func() ([]int, error) {
+		return slicesutils.ParallelMap(items, func(item int) int {
+			panic("worker failed")
+		}), nil
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "worker failed") {
+		t.Errorf("Expected the worker panic as an error, but got %v", err)
+	}
+}
+
+func TestChunkStep(t *testing.T) {
+	input := []int{1, 2, 3, 4, 5}
+	cases := []struct {
+		size, step int
+		expected   [][]int
+	}{
+		{2, 1, [][]int{{1, 2}, {2, 3}, {3, 4}, {4, 5}}},
+		{2, 2, [][]int{{1, 2}, {3, 4}, {5}}},
+		{1, 2, [][]int{{1}, {3}, {5}}},
+		{0, 1, [][]int{}},
+		{2, 0, [][]int{}},
+	}
+
+	for _, c := range cases {
+		result := slicesutils.ChunkStep(input, c.size, c.step)
+
+		if len(result) != len(c.expected) {
+			t.Errorf("Expected %v, but got %v", c.expected, result)
+			continue
+		}
+
+		for i, chunk := range result {
+			if ok := slicesutils.Compare(c.expected[i], chunk); !ok {
+				t.Errorf("Expected %v, but got %v", c.expected[i], chunk)
+			}
+		}
+	}
+}
+
+func TestChunkBy(t *testing.T) {
+	input := []int{1, 2, 3, 10, 11, 20, 30, 31}
+	expected := [][]int{{1, 2, 3}, {10, 11}, {20}, {30, 31}}
+
+	result := slicesutils.ChunkBy(input, func(prev, curr int) bool {
+		return curr-prev > 1
+	})
+
+	if len(result) != len(expected) {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	for i, chunk := range result {
+		if ok := slicesutils.Compare(expected[i], chunk); !ok {
+			t.Errorf("Expected %v, but got %v", expected[i], chunk)
+		}
+	}
+
+	if result := slicesutils.ChunkBy([]int{}, func(prev, curr int) bool { return true }); len(result) != 0 {
+		t.Errorf("Expected empty result, but got %v", result)
+	}
+}
+
+func TestScan(t *testing.T) {
+	result := slicesutils.Scan([]int{1, 2, 3}, func(acc, item int) int {
+		return acc + item
+	}, 0)
+
+	if ok := slicesutils.Compare([]int{1, 3, 6}, result); !ok {
+		t.Errorf("Expected %v, but got %v", []int{1, 3, 6}, result)
+	}
+}
+
+func TestGroupByOrdered(t *testing.T) {
+	input := []string{"banana", "apple", "cherry", "avocado", "blueberry"}
+
+	keys, groups := slicesutils.GroupByOrdered(input, func(item string) byte {
+		return item[0]
+	})
+
+	if ok := slicesutils.Compare([]byte{'b', 'a', 'c'}, keys); !ok {
+		t.Errorf("Expected keys %v, but got %v", []byte{'b', 'a', 'c'}, keys)
+	}
+
+	if ok := slicesutils.Compare([]string{"banana", "blueberry"}, groups['b']); !ok {
+		t.Errorf("Expected %v, but got %v", []string{"banana", "blueberry"}, groups['b'])
+	}
+
+	if ok := slicesutils.Compare([]string{"apple", "avocado"}, groups['a']); !ok {
+		t.Errorf("Expected %v, but got %v", []string{"apple", "avocado"}, groups['a'])
+	}
+}
+
+func TestIntersection(t *testing.T) {
+	a := []int{1, 2, 2, 3, 4, 4}
+	b := []int{4, 2, 4, 5, 2, 4}
+	expected := []int{4, 2}
+
+	result := slicesutils.Intersection(a, b)
+
+	if ok := slicesutils.Compare(expected, result); !ok {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+}
+
+func TestDifferenceCopy(t *testing.T) {
+	input := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	original := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	other := []int{1, 3, 5, 7}
+	expected := []int{2, 4, 6, 8, 9}
+
+	result := slicesutils.DifferenceCopy(input, other)
+
+	if ok := slicesutils.Compare(expected, result); !ok {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+
+	if ok := slicesutils.Compare(original, input); !ok {
+		t.Errorf("Expected input to remain %v, but got %v", original, input)
+	}
+}
+
+func TestSetOperationsBy(t *testing.T) {
+	a := []IdentifiableItem{{ID: 1, Type: "a1"}, {ID: 2, Type: "a2"}, {ID: 3, Type: "a3"}}
+	b := []IdentifiableItem{{ID: 3, Type: "b3"}, {ID: 4, Type: "b4"}, {ID: 2, Type: "b2"}, {ID: 3, Type: "b3"}}
+	byId := func(item IdentifiableItem) int {
+		return item.ID
+	}
+
+	intersection := slicesutils.IntersectionBy(a, b, byId)
+	expectedIntersection := []IdentifiableItem{{ID: 3, Type: "b3"}, {ID: 2, Type: "b2"}}
+	if ok := slicesutils.Compare(expectedIntersection, intersection); !ok {
+		t.Errorf("Expected %v, but got %v", expectedIntersection, intersection)
+	}
+
+	difference := slicesutils.DifferenceBy(a, b, byId)
+	expectedDifference := []IdentifiableItem{{ID: 1, Type: "a1"}}
+	if ok := slicesutils.Compare(expectedDifference, difference); !ok {
+		t.Errorf("Expected %v, but got %v", expectedDifference, difference)
+	}
+
+	union := slicesutils.UnionBy(a, b, byId)
+	expectedUnion := []IdentifiableItem{{ID: 1, Type: "a1"}, {ID: 2, Type: "a2"}, {ID: 3, Type: "a3"}, {ID: 4, Type: "b4"}}
+	if ok := slicesutils.Compare(expectedUnion, union); !ok {
+		t.Errorf("Expected %v, but got %v", expectedUnion, union)
+	}
+}
+
+func TestSymmetricDifference(t *testing.T) {
+	a := []int{1, 2, 2, 3, 4}
+	b := []int{3, 5, 4, 6, 5}
+	expected := []int{1, 2, 5, 6}
+
+	result := slicesutils.SymmetricDifference(a, b)
+
+	if ok := slicesutils.Compare(expected, result); !ok {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+}
+
+func TestContainsFunc(t *testing.T) {
+	input := []IdentifiableItem{{ID: 1, Type: "A"}, {ID: 2, Type: "B"}}
+
+	if !slicesutils.ContainsFunc(input, func(item IdentifiableItem) bool { return item.Type == "B" }) {
+		t.Errorf("Expected to find an item of type B")
+	}
+
+	if slicesutils.ContainsFunc(input, func(item IdentifiableItem) bool { return item.Type == "C" }) {
+		t.Errorf("Expected not to find an item of type C")
+	}
+}
+
+func TestCompareFunc(t *testing.T) {
+	closeEnough := func(x, y float64) bool {
+		return x-y < 0.01 && y-x < 0.01
+	}
+
+	if !slicesutils.CompareFunc([]float64{1, 2.001}, []float64{1.001, 2}, closeEnough) {
+		t.Errorf("Expected slices to be equal within tolerance")
+	}
+
+	if slicesutils.CompareFunc([]float64{1, 2.1}, []float64{1, 2}, closeEnough) {
+		t.Errorf("Expected slices to differ")
+	}
+
+	if slicesutils.CompareFunc([]float64{1}, []float64{1, 2}, closeEnough) {
+		t.Errorf("Expected slices of different length to differ")
+	}
+}
+
+func TestIndexOfSubslice(t *testing.T) {
+	haystack := []string{"the", "quick", "brown", "the", "lazy"}
+
+	cases := []struct {
+		needle   []string
+		expected int
+	}{
+		{[]string{"brown", "the"}, 2},
+		{[]string{"the"}, 0},
+		{[]string{}, 0},
+		{[]string{"the", "slow"}, -1},
+		{[]string{"the", "lazy", "dog"}, -1},
+	}
+
+	for _, c := range cases {
+		if index := slicesutils.IndexOfSubslice(haystack, c.needle); index != c.expected {
+			t.Errorf("Expected index %d for %v, but got %d", c.expected, c.needle, index)
+		}
+	}
+}
+
+func TestBinarySearch(t *testing.T) {
+	input := []int{1, 3, 3, 5, 7}
+
+	cases := []struct {
+		target        int
+		expectedIndex int
+		expectedFound bool
+	}{
+		{3, 1, true},
+		{7, 4, true},
+		{4, 3, false},
+		{0, 0, false},
+		{9, 5, false},
+	}
+
+	for _, c := range cases {
+		index, found := slicesutils.BinarySearch(input, c.target)
+		if index != c.expectedIndex || found != c.expectedFound {
+			t.Errorf("Expected (%d, %v) for %d, but got (%d, %v)", c.expectedIndex, c.expectedFound, c.target, index, found)
+		}
+	}
+}
+
+func TestBinarySearchFunc(t *testing.T) {
+	input := []IdentifiableItem{{ID: 1}, {ID: 4}, {ID: 6}, {ID: 9}}
+	byId := func(id int) func(IdentifiableItem) int {
+		return func(item IdentifiableItem) int {
+			return item.ID - id
+		}
+	}
+
+	if index, found := slicesutils.BinarySearchFunc(input, byId(6)); index != 2 || !found {
+		t.Errorf("Expected (2, true), but got (%d, %v)", index, found)
+	}
+
+	if index, found := slicesutils.BinarySearchFunc(input, byId(5)); index != 2 || found {
+		t.Errorf("Expected (2, false), but got (%d, %v)", index, found)
+	}
+
+	if index, found := slicesutils.BinarySearchFunc(input, byId(10)); index != 4 || found {
+		t.Errorf("Expected (4, false), but got (%d, %v)", index, found)
+	}
+}
+
+func TestIsSorted(t *testing.T) {
+	if !slicesutils.IsSorted([]int{1, 2, 2, 5}) {
+		t.Errorf("Expected slice to be sorted")
+	}
+
+	if slicesutils.IsSorted([]string{"b", "a"}) {
+		t.Errorf("Expected slice not to be sorted")
+	}
+
+	if !slicesutils.IsSorted([]int{}) || !slicesutils.IsSorted([]int{3}) {
+		t.Errorf("Expected empty and single-element slices to be sorted")
+	}
+}
+
+func TestIsSortedBy(t *testing.T) {
+	descending := func(a, b int) bool {
+		return a > b
+	}
+
+	if !slicesutils.IsSortedBy([]int{5, 3, 3, 1}, descending) {
+		t.Errorf("Expected slice to be sorted descending")
+	}
+
+	if slicesutils.IsSortedBy([]int{5, 6, 1}, descending) {
+		t.Errorf("Expected slice not to be sorted descending")
+	}
+}
+
+func TestSortBy(t *testing.T) {
+	input := []IdentifiableItem{{ID: 3}, {ID: 1}, {ID: 2}}
+	byId := func(item IdentifiableItem) int {
+		return item.ID
+	}
+
+	result := slicesutils.SortBy(input, byId)
+	if ok := slicesutils.Compare([]IdentifiableItem{{ID: 1}, {ID: 2}, {ID: 3}}, result); !ok {
+		t.Errorf("Expected ascending order, but got %v", result)
+	}
+
+	result = slicesutils.SortByDesc(input, byId)
+	if ok := slicesutils.Compare([]IdentifiableItem{{ID: 3}, {ID: 2}, {ID: 1}}, result); !ok {
+		t.Errorf("Expected descending order, but got %v", result)
+	}
+}
+
+func TestSortByKeys(t *testing.T) {
+	input := []IdentifiableItem{
+		{ID: 2, Type: "B"},
+		{ID: 3, Type: "A"},
+		{ID: 1, Type: "B"},
+		{ID: 1, Type: "A"},
+	}
+	expected := []IdentifiableItem{
+		{ID: 1, Type: "A"},
+		{ID: 3, Type: "A"},
+		{ID: 1, Type: "B"},
+		{ID: 2, Type: "B"},
+	}
+
+	result := slicesutils.SortByKeys(input,
+		func(a, b IdentifiableItem) int {
+			return strings.Compare(a.Type, b.Type)
+		},
+		func(a, b IdentifiableItem) int {
+			return a.ID - b.ID
+		},
+	)
+
+	if ok := slicesutils.Compare(expected, result); !ok {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+}
+
+func TestArgMinArgMax(t *testing.T) {
+	input := []float64{3, 1, 7, 1, 7}
+
+	if index, ok := slicesutils.ArgMin(input); index != 1 || !ok {
+		t.Errorf("Expected (1, true), but got (%d, %v)", index, ok)
+	}
+
+	if index, ok := slicesutils.ArgMax(input); index != 2 || !ok {
+		t.Errorf("Expected (2, true), but got (%d, %v)", index, ok)
+	}
+
+	if _, ok := slicesutils.ArgMin([]int{}); ok {
+		t.Errorf("Expected ArgMin of an empty slice to fail")
+	}
+
+	if _, ok := slicesutils.ArgMax([]int{}); ok {
+		t.Errorf("Expected ArgMax of an empty slice to fail")
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	cases := []struct {
+		input         []int
+		expectedMin   int
+		expectedMax   int
+		expectedFound bool
+	}{
+		{[]int{4, -2, 9, 0, 9, -2}, -2, 9, true},
+		{[]int{5, 4, 3, 2, 1}, 1, 5, true},
+		{[]int{7}, 7, 7, true},
+		{[]int{}, 0, 0, false},
+	}
+
+	for _, c := range cases {
+		minValue, maxValue, ok := slicesutils.MinMax(c.input)
+		if minValue != c.expectedMin || maxValue != c.expectedMax || ok != c.expectedFound {
+			t.Errorf("Expected (%d, %d, %v) for %v, but got (%d, %d, %v)", c.expectedMin, c.expectedMax, c.expectedFound, c.input, minValue, maxValue, ok)
+		}
+	}
+}
+
+func TestDedup(t *testing.T) {
+	cases := []struct {
+		input    []int
+		expected []int
+	}{
+		{[]int{1, 1, 2, 2, 1}, []int{1, 2, 1}},
+		{[]int{3, 3, 3}, []int{3}},
+		{[]int{1, 2, 3}, []int{1, 2, 3}},
+		{[]int{}, []int{}},
+	}
+
+	for _, c := range cases {
+		if result := slicesutils.Dedup(c.input); !slicesutils.Compare(c.expected, result) {
+			t.Errorf("Expected %v, but got %v", c.expected, result)
+		}
+	}
+}
+
+func TestDedupFunc(t *testing.T) {
+	input := []IdentifiableItem{{ID: 1, Type: "A"}, {ID: 1, Type: "B"}, {ID: 2, Type: "C"}, {ID: 1, Type: "D"}}
+	expected := []IdentifiableItem{{ID: 1, Type: "A"}, {ID: 2, Type: "C"}, {ID: 1, Type: "D"}}
+
+	result := slicesutils.DedupFunc(input, func(a, b IdentifiableItem) bool {
+		return a.ID == b.ID
+	})
+
+	if ok := slicesutils.Compare(expected, result); !ok {
+		t.Errorf("Expected %v, but got %v", expected, result)
+	}
+}